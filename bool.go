@@ -0,0 +1,68 @@
+package envconfig
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// boolWords maps the lower-case words accepted as booleans to their values.
+type boolWords map[string]bool
+
+var (
+	defaultTrueWords  = []string{"1", "t", "true", "y", "yes", "on", "enable", "enabled"}
+	defaultFalseWords = []string{"0", "f", "false", "n", "no", "off", "disable", "disabled"}
+)
+
+var boolVocabulary atomic.Pointer[boolWords]
+
+func init() {
+	words, _ := newBoolWords(defaultTrueWords, defaultFalseWords)
+	boolVocabulary.Store(&words)
+}
+
+// SetBoolWords sets the words that LoadStruct and the getters accept as
+// true and false, compared case-insensitively. Any other value is an error,
+// never false. Pass nil for both to restore the default vocabulary:
+// 1, t, true, y, yes, on, enable, enabled and 0, f, false, n, no, off,
+// disable, disabled. It is safe to call concurrently with the getters.
+//
+// Example:
+//
+//	err := envconfig.SetBoolWords([]string{"true", "да"}, []string{"false", "нет"})
+func SetBoolWords(trueWords, falseWords []string) error {
+	if trueWords == nil && falseWords == nil {
+		trueWords, falseWords = defaultTrueWords, defaultFalseWords
+	}
+	words, err := newBoolWords(trueWords, falseWords)
+	if err != nil {
+		return err
+	}
+	boolVocabulary.Store(&words)
+	return nil
+}
+
+func newBoolWords(trueWords, falseWords []string) (boolWords, error) {
+	words := make(boolWords, len(trueWords)+len(falseWords))
+	for _, w := range trueWords {
+		words[strings.ToLower(w)] = true
+	}
+	for _, w := range falseWords {
+		w = strings.ToLower(w)
+		if words[w] {
+			return nil, fmt.Errorf("envconfig: %q is both a true and a false word", w)
+		}
+		words[w] = false
+	}
+	return words, nil
+}
+
+// parseBool parses value using the vocabulary set by SetBoolWords.
+func parseBool(value string) (bool, error) {
+	words := *boolVocabulary.Load()
+	v, ok := words[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return false, fmt.Errorf("invalid boolean %q", value)
+	}
+	return v, nil
+}
@@ -0,0 +1,77 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadStructBoolWords(t *testing.T) {
+	type config struct {
+		Debug   bool   `env:"BOOL_DEBUG"`
+		Modules []bool `env:"BOOL_MODULES"`
+	}
+
+	tests := []struct {
+		name    string
+		debug   string
+		modules string
+		want    bool
+		wantErr string
+	}{
+		{name: "on", debug: "on", want: true},
+		{name: "disabled", debug: "Disabled", want: false},
+		{name: "list", debug: "y", modules: "yes,no,ON", want: true},
+		{name: "unknown word", debug: "maybe", wantErr: `env BOOL_DEBUG: invalid boolean "maybe"`},
+		{name: "unknown word in list", debug: "1", modules: "on,nah", wantErr: `env BOOL_MODULES: invalid bool value at index 1: invalid boolean "nah"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BOOL_DEBUG", tt.debug)
+			t.Setenv("BOOL_MODULES", tt.modules)
+
+			var cfg config
+			err := LoadStruct(&cfg)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("LoadStruct() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if cfg.Debug != tt.want {
+				t.Errorf("Debug = %v, want %v", cfg.Debug, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetBoolWords(t *testing.T) {
+	t.Cleanup(func() { SetBoolWords(nil, nil) })
+
+	if err := SetBoolWords([]string{"да"}, []string{"нет"}); err != nil {
+		t.Fatalf("SetBoolWords() error = %v", err)
+	}
+
+	t.Setenv("BOOL_WORDS", "ДА")
+	if got, err := GetBoolE("BOOL_WORDS", false); err != nil || !got {
+		t.Errorf("GetBoolE() = %v, %v, want true", got, err)
+	}
+	t.Setenv("BOOL_WORDS", "yes")
+	if _, err := GetBoolE("BOOL_WORDS", false); err == nil {
+		t.Error("GetBoolE() error = nil, want error for word outside the vocabulary")
+	}
+
+	if err := SetBoolWords([]string{"on"}, []string{"ON"}); err == nil || !strings.Contains(err.Error(), `"on" is both a true and a false word`) {
+		t.Errorf("SetBoolWords() error = %v", err)
+	}
+
+	if err := SetBoolWords(nil, nil); err != nil {
+		t.Fatalf("SetBoolWords(nil, nil) error = %v", err)
+	}
+	if got := GetBool("BOOL_WORDS", false); !got {
+		t.Error("GetBool() = false after restoring the default vocabulary")
+	}
+}
@@ -0,0 +1,115 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError reports a problem with the declaration of a struct field.
+type FieldError struct {
+	// Path is the Go path of the field, e.g. "DB.Port".
+	Path string
+	// Env is the name of the environment variable the field maps to.
+	Env string
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s (env %s): %v", e.Path, e.Env, e.Err)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// CheckStruct reports programming errors in the tags of cfg without reading
+// the environment: environment variable names used by more than one field,
+// defaults that cannot be parsed as the field type, unsupported field types,
+// malformed or conflicting boolean tags and tagged unexported fields,
+// which LoadStruct could never set. The returned error joins a *FieldError for every problem.
+// cfg must be a pointer to a struct. Options that affect variable names,
+// such as WithAutoNames, must match those passed to LoadStruct.
+//
+// LoadStruct runs the same checks before loading. CheckStruct is meant
+// for unit tests:
+//
+//	func TestConfig(t *testing.T) {
+//	    if err := envconfig.CheckStruct(&Config{}); err != nil {
+//	        t.Fatal(err)
+//	    }
+//	}
+func CheckStruct(cfg any, opts ...Option) error {
+	specs, err := newLoader(opts).collectFields(cfg)
+	if err != nil {
+		return err
+	}
+	return checkSpecs(specs)
+}
+
+// checkSpecs validates the field declarations described by specs.
+func checkSpecs(specs []fieldSpec) error {
+	var errs []error
+	fail := func(spec fieldSpec, format string, args ...any) {
+		errs = append(errs, &FieldError{Path: spec.path, Env: spec.envName, Err: fmt.Errorf(format, args...)})
+	}
+
+	seen := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		for _, name := range spec.names() {
+			if other, ok := seen[name]; ok {
+				fail(spec, "variable %s is already used by field %s", name, other)
+			} else {
+				seen[name] = spec.path
+			}
+		}
+
+		if !spec.value.CanSet() {
+			fail(spec, "env tag on unexported field")
+			continue
+		}
+
+		for _, name := range []string{"required", "secret", "notEmpty", "allowEmpty", "unquote"} {
+			if tag, ok := spec.field.Tag.Lookup(name); ok {
+				if _, err := strconv.ParseBool(tag); err != nil {
+					fail(spec, "invalid %s tag %q", name, tag)
+				}
+			}
+		}
+
+		if tag, ok := spec.field.Tag.Lookup("deprecated"); ok && strings.TrimSpace(tag) == "" {
+			fail(spec, "empty deprecated tag, want a boolean or a message")
+		}
+
+		if spec.notEmpty && spec.allowEmpty {
+			fail(spec, "notEmpty and allowEmpty tags are mutually exclusive")
+		}
+
+		if _, err := applyTransforms("", spec.transform); err != nil {
+			fail(spec, "%w", err)
+		}
+
+		if spec.enum != "" {
+			elem := reflect.New(enumElemType(spec.field.Type)).Elem()
+			for _, e := range parseEnumTag(spec.enum) {
+				if err := setValue(elem, e.value, decodeOptions{}); err != nil {
+					fail(spec, "invalid enum value %s=%s: %w", e.name, e.value, err)
+				}
+			}
+		}
+
+		scratch := reflect.New(spec.field.Type).Elem()
+		if err := setValue(scratch, spec.defaultValue, spec.decodeOptions(EmptyAsUnset)); err != nil {
+			if spec.secret {
+				err = redactError(err, spec.defaultValue)
+			}
+			if spec.hasDefault {
+				fail(spec, "invalid default: %w", err)
+			} else {
+				fail(spec, "%w", err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,125 @@
+package envconfig
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckStruct(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  any
+		want []string
+	}{
+		{
+			name: "valid struct",
+			cfg: &struct {
+				Host  string `env:"HOST" default:"localhost"`
+				Port  int    `env:"PORT" default:"8080" required:"true"`
+				Ports []int  `env:"PORTS" default:"1,2"`
+			}{},
+		},
+		{
+			name: "duplicate env names",
+			cfg: &struct {
+				Host string `env:"HOST"`
+				DB   struct {
+					Host string `env:"HOST"`
+				} `prefix:"DB_"`
+				Addr string `env:"DB_HOST"`
+			}{},
+			want: []string{"field Addr (env DB_HOST): variable DB_HOST is already used by field DB.Host"},
+		},
+		{
+			name: "alias used by another field",
+			cfg: &struct {
+				Host string `env:"HOST" aliases:"ADDR"`
+				Addr string `env:"ADDR"`
+			}{},
+			want: []string{"field Addr (env ADDR): variable ADDR is already used by field Host"},
+		},
+		{
+			name: "invalid default",
+			cfg: &struct {
+				Port int `env:"PORT" default:"80a"`
+			}{},
+			want: []string{`field Port (env PORT): invalid default: strconv.ParseInt: parsing "80a": invalid syntax`},
+		},
+		{
+			name: "invalid secret default",
+			cfg: &struct {
+				PIN int `env:"PIN" default:"hunter2" secret:"true"`
+			}{},
+			want: []string{`field PIN (env PIN): invalid default: strconv.ParseInt: parsing "******": invalid syntax`},
+		},
+		{
+			name: "empty deprecated tag",
+			cfg: &struct {
+				Mode string `env:"MODE" deprecated:""`
+			}{},
+			want: []string{"field Mode (env MODE): empty deprecated tag, want a boolean or a message"},
+		},
+		{
+			name: "unexported field",
+			cfg: &struct {
+				host string `env:"HOST"`
+			}{},
+			want: []string{"field host (env HOST): env tag on unexported field"},
+		},
+		{
+			name: "unsupported type",
+			cfg: &struct {
+				Ratio complex64 `env:"RATIO"`
+			}{},
+			want: []string{"field Ratio (env RATIO): unsupported kind: complex64"},
+		},
+		{
+			name: "malformed boolean tag",
+			cfg: &struct {
+				Token string `env:"TOKEN" secret:"yes"`
+			}{},
+			want: []string{`field Token (env TOKEN): invalid secret tag "yes"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStruct(tt.cfg)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("CheckStruct() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("CheckStruct() error = nil, want %v", tt.want)
+			}
+
+			got := strings.Split(err.Error(), "\n")
+			if len(got) != len(tt.want) {
+				t.Fatalf("CheckStruct() error = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("CheckStruct() error[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Errorf("CheckStruct() error does not contain *FieldError")
+			}
+		})
+	}
+}
+
+func TestLoadStructRunsChecks(t *testing.T) {
+	var cfg struct {
+		A string `env:"TEST_CHECK_DUP"`
+		B string `env:"TEST_CHECK_DUP"`
+	}
+	if err := LoadStruct(&cfg); err == nil {
+		t.Error("LoadStruct() error = nil, want duplicate variable error")
+	}
+}
@@ -0,0 +1,46 @@
+package envconfig
+
+import "fmt"
+
+// DeprecatedError is the warning LoadStruct reports through the warning handler
+// (see OnWarning) when a deprecated variable name is set.
+type DeprecatedError struct {
+	// Name is the deprecated variable.
+	Name string
+	// Replacement is the variable to use instead, if Name is an alias.
+	Replacement string
+	// Message is the text of the "deprecated" tag, if any.
+	Message string
+}
+
+func (e *DeprecatedError) Error() string {
+	msg := fmt.Sprintf("variable %s is deprecated", e.Name)
+	if e.Replacement != "" {
+		msg += ", use " + e.Replacement
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// warnDeprecated reports every alias of spec that is set, and the variable
+// itself if the field is tagged as deprecated.
+func (l *loader) warnDeprecated(spec fieldSpec) {
+	message := spec.deprecated
+	if isTrue(message) {
+		message = ""
+	}
+
+	if spec.deprecated != "" {
+		if _, exists := l.source.Lookup(spec.envName); exists {
+			l.warn(&DeprecatedError{Name: spec.envName, Message: message})
+		}
+	}
+
+	for _, alias := range spec.aliases {
+		if _, exists := l.source.Lookup(alias); exists {
+			l.warn(&DeprecatedError{Name: alias, Replacement: spec.envName, Message: message})
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package envconfig
+
+import (
+	"errors"
+	"testing"
+)
+
+type aliasConfig struct {
+	URL   string `env:"TEST_ALIAS_DATABASE_URL" aliases:"TEST_ALIAS_DB_URL,TEST_ALIAS_DSN"`
+	Mode  string `env:"TEST_ALIAS_MODE" deprecated:"modes are detected automatically"`
+	Level string `env:"TEST_ALIAS_LEVEL" deprecated:"true"`
+	Port  string `env:"TEST_ALIAS_PORT" deprecated:"false"`
+}
+
+func TestLoadStructAliases(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		want         string
+		wantOrigin   Origin
+		wantWarnings []string
+		wantErr      bool
+	}{
+		{
+			name:       "new name",
+			env:        map[string]string{"TEST_ALIAS_DATABASE_URL": "new"},
+			want:       "new",
+			wantOrigin: Origin{Kind: SourceEnv, Key: "TEST_ALIAS_DATABASE_URL"},
+		},
+		{
+			name:         "old name",
+			env:          map[string]string{"TEST_ALIAS_DSN": "old"},
+			want:         "old",
+			wantOrigin:   Origin{Kind: SourceAlias, Key: "TEST_ALIAS_DSN"},
+			wantWarnings: []string{"variable TEST_ALIAS_DSN is deprecated, use TEST_ALIAS_DATABASE_URL"},
+		},
+		{
+			name: "aliases in listed order",
+			env: map[string]string{
+				"TEST_ALIAS_DB_URL": "same",
+				"TEST_ALIAS_DSN":    "same",
+			},
+			want:       "same",
+			wantOrigin: Origin{Kind: SourceAlias, Key: "TEST_ALIAS_DB_URL"},
+			wantWarnings: []string{
+				"variable TEST_ALIAS_DB_URL is deprecated, use TEST_ALIAS_DATABASE_URL",
+				"variable TEST_ALIAS_DSN is deprecated, use TEST_ALIAS_DATABASE_URL",
+			},
+		},
+		{
+			name: "conflicting values",
+			env: map[string]string{
+				"TEST_ALIAS_DATABASE_URL": "new",
+				"TEST_ALIAS_DSN":          "old",
+			},
+			wantErr: true,
+		},
+		{
+			name:         "deprecated variable",
+			env:          map[string]string{"TEST_ALIAS_MODE": "fast"},
+			wantOrigin:   Origin{Kind: SourceNone},
+			wantWarnings: []string{"variable TEST_ALIAS_MODE is deprecated: modes are detected automatically"},
+		},
+		{
+			name:         "deprecated without message",
+			env:          map[string]string{"TEST_ALIAS_LEVEL": "debug"},
+			wantOrigin:   Origin{Kind: SourceNone},
+			wantWarnings: []string{"variable TEST_ALIAS_LEVEL is deprecated"},
+		},
+		{
+			name:       "not deprecated",
+			env:        map[string]string{"TEST_ALIAS_PORT": "80"},
+			wantOrigin: Origin{Kind: SourceNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			var warnings []string
+			var md Metadata
+			var cfg aliasConfig
+			err := LoadStruct(&cfg, WithMetadata(&md), OnWarning(func(err error) {
+				var deprecated *DeprecatedError
+				if !errors.As(err, &deprecated) {
+					t.Errorf("warning = %T, want *DeprecatedError", err)
+				}
+				warnings = append(warnings, err.Error())
+			}))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadStruct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if cfg.URL != tt.want {
+				t.Errorf("URL = %q, want %q", cfg.URL, tt.want)
+			}
+			if origin, _ := md.Origin("URL"); origin != tt.wantOrigin {
+				t.Errorf("Origin(URL) = %+v, want %+v", origin, tt.wantOrigin)
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("warnings = %q, want %q", warnings, tt.wantWarnings)
+			}
+			for i := range warnings {
+				if warnings[i] != tt.wantWarnings[i] {
+					t.Errorf("warnings[%d] = %q, want %q", i, warnings[i], tt.wantWarnings[i])
+				}
+			}
+		})
+	}
+}
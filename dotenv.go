@@ -0,0 +1,464 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SyntaxError reports a malformed line in a .env file.
+type SyntaxError struct {
+	// File is the name of the file, or "" if the input was not read from a file.
+	File string
+	// Line is the line the malformed entry starts on.
+	Line int
+	Msg  string
+	// Err is the underlying error, e.g. of reading an included file, or nil.
+	Err error
+}
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+func (e *SyntaxError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// EnvNodeKind is the kind of an EnvNode.
+type EnvNodeKind int
+
+const (
+	// BlankNode is an empty or whitespace-only line.
+	BlankNode EnvNodeKind = iota
+	// CommentNode is a line starting with "#".
+	CommentNode
+	// AssignmentNode is a KEY=value entry, possibly spanning several lines.
+	AssignmentNode
+	// IncludeNode is an "#include path" or "@import path" directive.
+	IncludeNode
+)
+
+// EnvNode is a single entry of a parsed .env file.
+type EnvNode struct {
+	Kind EnvNodeKind
+	// Line is the line the entry starts on.
+	Line int
+	// Raw is the source text of the entry without the final line break.
+	Raw string
+
+	// Key is the variable name of an assignment.
+	Key string
+	// Value is the value of an assignment with quotes removed, escape
+	// sequences and variable references expanded.
+	Value string
+	// Quote is the quote character the value was written in: '\'', '"' or 0.
+	Quote byte
+	// Export is true if the assignment has the "export" prefix.
+	Export bool
+	// Comment is the text after "#" of a comment line or of an inline
+	// comment following an assignment, without the "#".
+	Comment string
+	// Path is the file named by an include directive, as written.
+	Path string
+}
+
+// EnvFile is a parsed .env file. It keeps every line, including comments
+// and blank lines, so that the source can be inspected or rewritten.
+type EnvFile struct {
+	// Name is the name the file was parsed under, used in errors.
+	Name  string
+	Nodes []EnvNode
+}
+
+// Vars returns the variables assigned in the file. If a key is assigned
+// more than once, the last assignment wins. Include directives are not followed.
+func (f *EnvFile) Vars() map[string]string {
+	vars := make(map[string]string)
+	for _, node := range f.Nodes {
+		if node.Kind == AssignmentNode {
+			vars[node.Key] = node.Value
+		}
+	}
+	return vars
+}
+
+// String returns the source of the file.
+func (f *EnvFile) String() string {
+	var sb strings.Builder
+	for _, node := range f.Nodes {
+		sb.WriteString(node.Raw)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// ParseEnvFile reads and parses the .env file at path. See ParseEnv.
+func ParseEnvFile(path string) (*EnvFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseEnv(string(data), path)
+}
+
+// ParseEnv parses a .env file read from r. name is used in errors.
+//
+// The syntax is compatible with docker compose and bash:
+//
+//	# comment
+//	export KEY=value          # "export" is optional
+//	PLAIN=unquoted value      # inline comments need whitespace before "#"
+//	SINGLE='taken literally'
+//	DOUBLE="escapes: \n \t \" \\ \$"
+//	MULTI="first line
+//	second line"
+//	URL=http://${HOST}:${PORT:-8080}
+//
+// Values in single quotes are taken literally. Unquoted and double-quoted values
+// expand $NAME, ${NAME}, ${NAME:-default} (default if unset or empty) and
+// ${NAME-default} (default if unset), looking NAME up among the keys assigned
+// earlier in the file and then in the process environment. Quoted values may span
+// several lines. Errors are *SyntaxError values such as ".env:14: unterminated quote".
+//
+// The lines "#include path" and "@import path" are parsed as IncludeNode
+// entries, but ParseEnv does not read the named files; Load does.
+// Any other line starting with "#" is a comment.
+func ParseEnv(r io.Reader, name string) (*EnvFile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseEnv(string(data), name)
+}
+
+// envParser holds the state of parseEnv.
+type envParser struct {
+	src  string
+	name string
+	pos  int
+	line int
+	// vars holds the keys assigned so far, for variable expansion.
+	vars map[string]string
+	// own holds the keys assigned in this file rather than included.
+	own map[string]bool
+	// include reads the file named by an include directive and returns its
+	// variables. If it is nil, include directives are not followed.
+	include func(path string) (map[string]string, error)
+}
+
+func parseEnv(src, name string) (*EnvFile, error) {
+	return newEnvParser(src, name).parse()
+}
+
+func newEnvParser(src, name string) *envParser {
+	return &envParser{
+		src:  strings.TrimPrefix(src, "\uFEFF"),
+		name: name,
+		line: 1,
+		vars: make(map[string]string),
+		own:  make(map[string]bool),
+	}
+}
+
+func (p *envParser) parse() (*EnvFile, error) {
+	file := &EnvFile{Name: p.name}
+	for p.pos < len(p.src) {
+		start, line := p.pos, p.line
+		node, err := p.parseNode()
+		if err != nil {
+			return nil, err
+		}
+		node.Line = line
+		node.Raw = strings.TrimSuffix(strings.TrimSuffix(p.src[start:p.pos], "\n"), "\r")
+		p.line += strings.Count(p.src[start:p.pos], "\n")
+		switch node.Kind {
+		case AssignmentNode:
+			p.vars[node.Key] = node.Value
+			p.own[node.Key] = true
+		case IncludeNode:
+			if err := p.followInclude(node); err != nil {
+				return nil, err
+			}
+		}
+		file.Nodes = append(file.Nodes, node)
+	}
+	return file, nil
+}
+
+// followInclude passes the path of an include directive to p.include and
+// makes the variables of the included file available for expansion.
+func (p *envParser) followInclude(node EnvNode) error {
+	if p.include == nil {
+		return nil
+	}
+	vars, err := p.include(node.Path)
+	if err != nil {
+		var syntaxErr *SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return err
+		}
+		return &SyntaxError{File: p.name, Line: node.Line, Msg: err.Error(), Err: err}
+	}
+	for key, value := range vars {
+		if !p.own[key] {
+			p.vars[key] = value
+		}
+	}
+	return nil
+}
+
+func (p *envParser) errorf(format string, args ...any) error {
+	return &SyntaxError{File: p.name, Line: p.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parseNode parses one entry and the line break that ends it.
+func (p *envParser) parseNode() (EnvNode, error) {
+	p.skipSpaces()
+
+	switch {
+	case p.atEOL():
+		p.skipEOL()
+		return EnvNode{Kind: BlankNode}, nil
+	case p.src[p.pos] == '#' || p.src[p.pos] == '@':
+		text := p.restOfLine()
+		if path, ok := includePath(text); ok {
+			if path == "" {
+				return EnvNode{}, p.errorf("missing include path")
+			}
+			p.skipEOL()
+			return EnvNode{Kind: IncludeNode, Path: path}, nil
+		}
+		if text[0] == '@' {
+			return EnvNode{}, p.errorf("invalid variable name %q", strings.TrimSpace(text))
+		}
+		p.skipEOL()
+		return EnvNode{Kind: CommentNode, Comment: text[1:]}, nil
+	}
+
+	node := EnvNode{Kind: AssignmentNode}
+	if rest := p.src[p.pos:]; strings.HasPrefix(rest, "export") && len(rest) > 6 && (rest[6] == ' ' || rest[6] == '\t') {
+		node.Export = true
+		p.pos += 6
+		p.skipSpaces()
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && isKeyChar(p.src[p.pos], p.pos == start) {
+		p.pos++
+	}
+	node.Key = p.src[start:p.pos]
+	if node.Key == "" {
+		return node, p.errorf("invalid variable name %q", strings.TrimSpace(p.restOfLine()))
+	}
+
+	p.skipSpaces()
+	if p.pos >= len(p.src) || (p.src[p.pos] != '=' && p.src[p.pos] != ':') {
+		return node, p.errorf("expected \"=\" after %s", node.Key)
+	}
+	p.pos++
+	p.skipSpaces()
+
+	var err error
+	switch {
+	case p.atEOL():
+	case p.src[p.pos] == '\'' || p.src[p.pos] == '"':
+		err = p.parseQuoted(&node)
+	default:
+		err = p.parseUnquoted(&node)
+	}
+	if err != nil {
+		return node, err
+	}
+
+	p.skipEOL()
+	return node, nil
+}
+
+// parseQuoted parses a value in single or double quotes and what follows it
+// on the line.
+func (p *envParser) parseQuoted(node *EnvNode) error {
+	quote := p.src[p.pos]
+	p.pos++
+
+	start := p.pos
+	for {
+		if p.pos >= len(p.src) {
+			return p.errorf("unterminated quote")
+		}
+		c := p.src[p.pos]
+		if c == '\\' && quote == '"' {
+			p.pos += 2
+			continue
+		}
+		if c == quote {
+			break
+		}
+		p.pos++
+	}
+	raw := p.src[start:p.pos]
+	p.pos++
+
+	node.Quote = quote
+	if quote == '\'' {
+		node.Value = raw
+	} else {
+		value, err := p.expand(raw, true)
+		if err != nil {
+			return err
+		}
+		node.Value = value
+	}
+
+	p.skipSpaces()
+	switch {
+	case p.atEOL():
+	case p.src[p.pos] == '#':
+		node.Comment = p.restOfLine()[1:]
+	default:
+		return p.errorf("unexpected %q after quoted value", p.src[p.pos])
+	}
+	return nil
+}
+
+// parseUnquoted parses an unquoted value up to the end of the line
+// or an inline comment.
+func (p *envParser) parseUnquoted(node *EnvNode) error {
+	raw := p.restOfLine()
+	for i := 1; i < len(raw); i++ {
+		if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			node.Comment = raw[i+1:]
+			raw = raw[:i]
+			break
+		}
+	}
+
+	value, err := p.expand(strings.TrimRight(raw, " \t"), false)
+	if err != nil {
+		return err
+	}
+	node.Value = value
+	return nil
+}
+
+// expand expands variable references in s and, if escapes is true,
+// the escape sequences of double-quoted values.
+func (p *envParser) expand(s string, escapes bool) (string, error) {
+	if !strings.ContainsAny(s, `$\`) {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && escapes && i+1 < len(s):
+			if r, ok := escapeChar(s[i+1]); ok {
+				sb.WriteByte(r)
+				i++
+				continue
+			}
+			sb.WriteByte(c)
+
+		case c == '$' && i+1 < len(s) && s[i+1] == '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", p.errorf("unterminated variable reference")
+			}
+			sb.WriteString(p.lookup(s[i+2 : i+2+end]))
+			i += 2 + end
+
+		case c == '$' && i+1 < len(s) && isKeyChar(s[i+1], true):
+			end := i + 1
+			for end < len(s) && isKeyChar(s[end], false) && s[end] != '.' {
+				end++
+			}
+			sb.WriteString(p.lookup(s[i+1 : end]))
+			i = end - 1
+
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String(), nil
+}
+
+// lookup resolves a variable reference: NAME, NAME:-default or NAME-default.
+func (p *envParser) lookup(ref string) string {
+	name, def, hasDef := ref, "", false
+	colon := false
+	if i := strings.Index(ref, ":-"); i >= 0 {
+		name, def, hasDef, colon = ref[:i], ref[i+2:], true, true
+	} else if i := strings.IndexByte(ref, '-'); i >= 0 {
+		name, def, hasDef = ref[:i], ref[i+1:], true
+	}
+
+	value, ok := p.vars[name]
+	if !ok {
+		value, ok = os.LookupEnv(name)
+	}
+	if hasDef && (!ok || (colon && value == "")) {
+		return def
+	}
+	return value
+}
+
+func (p *envParser) atEOL() bool {
+	return p.pos >= len(p.src) || p.src[p.pos] == '\n' || strings.HasPrefix(p.src[p.pos:], "\r\n")
+}
+
+func (p *envParser) skipSpaces() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *envParser) skipEOL() {
+	if strings.HasPrefix(p.src[p.pos:], "\r\n") {
+		p.pos += 2
+	} else if p.pos < len(p.src) && p.src[p.pos] == '\n' {
+		p.pos++
+	}
+}
+
+// restOfLine consumes and returns the text up to the end of the line.
+func (p *envParser) restOfLine() string {
+	end := strings.IndexByte(p.src[p.pos:], '\n')
+	if end < 0 {
+		end = len(p.src) - p.pos
+	}
+	line := strings.TrimSuffix(p.src[p.pos:p.pos+end], "\r")
+	p.pos += len(line)
+	return line
+}
+
+// includePath returns the path of an "#include path" or "@import path"
+// line, with optional quotes removed, and whether line is such a directive.
+func includePath(line string) (string, bool) {
+	for _, directive := range []string{"#include", "@import"} {
+		rest, ok := strings.CutPrefix(line, directive)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		path := strings.TrimSpace(rest)
+		if len(path) >= 2 && (path[0] == '"' || path[0] == '\'') && path[len(path)-1] == path[0] {
+			path = path[1 : len(path)-1]
+		}
+		return path, true
+	}
+	return "", false
+}
+
+// isKeyChar reports whether c may appear in a variable name.
+func isKeyChar(c byte, first bool) bool {
+	switch {
+	case c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z'):
+		return true
+	case c == '.' || (c >= '0' && c <= '9'):
+		return !first
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseEnv(t *testing.T) {
+	t.Setenv("PARSE_FROM_ENV", "env-value")
+
+	src := "# header comment\n" +
+		"\n" +
+		"PLAIN=value\n" +
+		"export EXPORTED=yes\n" +
+		"  SPACED = padded value  \n" +
+		"INLINE=abc # comment\n" +
+		"HASH=abc#def\n" +
+		"EMPTY=\n" +
+		"SINGLE='literal $PLAIN \\n'\n" +
+		"DOUBLE=\"tab\\tnewline\\nquote\\\" dollar\\$PLAIN\"\n" +
+		"MULTI=\"line1\n" +
+		"line2\" # after\n" +
+		"SINGLE_MULTI='a\n" +
+		"b'\n" +
+		"REF=${PLAIN}-$PLAIN\n" +
+		"REF_ENV=$PARSE_FROM_ENV\n" +
+		"REF_DEFAULT=${PARSE_MISSING:-fallback}\n" +
+		"REF_EMPTY=${EMPTY:-fallback},${EMPTY-kept}\n" +
+		"YAML: style\n" +
+		"CRLF=value\r\n" +
+		"LAST=1\n" +
+		"LAST=2"
+
+	file, err := ParseEnv(strings.NewReader(src), ".env")
+	if err != nil {
+		t.Fatalf("ParseEnv() error = %v", err)
+	}
+
+	want := map[string]string{
+		"PLAIN":        "value",
+		"EXPORTED":     "yes",
+		"SPACED":       "padded value",
+		"INLINE":       "abc",
+		"HASH":         "abc#def",
+		"EMPTY":        "",
+		"SINGLE":       `literal $PLAIN \n`,
+		"DOUBLE":       "tab\tnewline\nquote\" dollar$PLAIN",
+		"MULTI":        "line1\nline2",
+		"SINGLE_MULTI": "a\nb",
+		"REF":          "value-value",
+		"REF_ENV":      "env-value",
+		"REF_DEFAULT":  "fallback",
+		"REF_EMPTY":    "fallback,",
+		"YAML":         "style",
+		"CRLF":         "value",
+		"LAST":         "2",
+	}
+	got := file.Vars()
+	for key, w := range want {
+		if got[key] != w {
+			t.Errorf("%s = %q, want %q", key, got[key], w)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("Vars() has %d keys, want %d: %v", len(got), len(want), got)
+	}
+
+	if file.String() != strings.ReplaceAll(src, "\r\n", "\n")+"\n" {
+		t.Errorf("String() does not reproduce the source:\n%s", file.String())
+	}
+}
+
+func TestParseEnvNodes(t *testing.T) {
+	src := "# first\n\nexport A=1 # one\nB=\"x\ny\"\nC=3\n#include ../shared.env\n@import \"base.env\"\n#includes\n"
+
+	file, err := ParseEnv(strings.NewReader(src), ".env")
+	if err != nil {
+		t.Fatalf("ParseEnv() error = %v", err)
+	}
+
+	want := []EnvNode{
+		{Kind: CommentNode, Line: 1, Raw: "# first", Comment: " first"},
+		{Kind: BlankNode, Line: 2, Raw: ""},
+		{Kind: AssignmentNode, Line: 3, Raw: "export A=1 # one", Key: "A", Value: "1", Export: true, Comment: " one"},
+		{Kind: AssignmentNode, Line: 4, Raw: "B=\"x\ny\"", Key: "B", Value: "x\ny", Quote: '"'},
+		{Kind: AssignmentNode, Line: 6, Raw: "C=3", Key: "C", Value: "3"},
+		{Kind: IncludeNode, Line: 7, Raw: "#include ../shared.env", Path: "../shared.env"},
+		{Kind: IncludeNode, Line: 8, Raw: `@import "base.env"`, Path: "base.env"},
+		{Kind: CommentNode, Line: 9, Raw: "#includes", Comment: "includes"},
+	}
+	if len(file.Nodes) != len(want) {
+		t.Fatalf("Nodes = %+v", file.Nodes)
+	}
+	for i, node := range file.Nodes {
+		if node != want[i] {
+			t.Errorf("Nodes[%d] = %+v, want %+v", i, node, want[i])
+		}
+	}
+}
+
+func TestParseEnvErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"unterminated double quote", "A=1\nB=\"open\nC=3\n", `.env:2: unterminated quote`},
+		{"unterminated single quote", "A='open", `.env:1: unterminated quote`},
+		{"escaped closing quote", `A="open\"`, `.env:1: unterminated quote`},
+		{"missing equals", "A=1\n\nJUSTKEY\n", `.env:3: expected "=" after JUSTKEY`},
+		{"invalid name", "1A=x", `.env:1: invalid variable name "1A=x"`},
+		{"text after quotes", `A="x" y`, `.env:1: unexpected 'y' after quoted value`},
+		{"unterminated reference", "A=${B", `.env:1: unterminated variable reference`},
+		{"line after multiline value", "A=\"1\n2\"\nB=\"x", `.env:3: unterminated quote`},
+		{"include without path", "A=1\n#include   \n", `.env:2: missing include path`},
+		{"unknown directive", "@export A=1", `.env:1: invalid variable name "@export A=1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEnv(strings.NewReader(tt.src), ".env")
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseEnv() error = %v, want *SyntaxError", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("ParseEnv() error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSyntaxError(t *testing.T) {
+	resetDotEnv(t)
+	envFile := filepath.Join(t.TempDir(), "app.env")
+	if err := os.WriteFile(envFile, []byte("LOAD_SYNTAX_A=1\nLOAD_SYNTAX_B='x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvFileKey, envFile)
+
+	err := Load()
+	if err == nil || err.Error() != envFile+":2: unterminated quote" {
+		t.Errorf("Load() error = %v", err)
+	}
+	if _, exists := os.LookupEnv("LOAD_SYNTAX_A"); exists {
+		t.Error("Load() set variables from a file with a syntax error")
+	}
+}
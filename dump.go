@@ -0,0 +1,215 @@
+package envconfig
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// DumpEntry describes the effective value of a single configuration field.
+type DumpEntry struct {
+	// Path is the Go path of the field, e.g. "DB.Host".
+	Path string `json:"path"`
+	// Env is the name of the environment variable.
+	Env string `json:"env"`
+	// Value is the field value in environment variable syntax,
+	// or RedactedValue if the field is secret.
+	Value string `json:"value"`
+	// Source tells where the value came from, as returned by Origin.String,
+	// e.g. "env", "default" or ".env:14". It is empty unless Dump is given
+	// the Metadata recorded by LoadStruct.
+	Source string `json:"source,omitempty"`
+}
+
+// DumpEntries is an ordered list of configuration values returned by Dump.
+type DumpEntries []DumpEntry
+
+// Dump returns the effective values of every field of cfg that LoadStruct
+// reads, in declaration order. Values of secret fields are masked.
+// cfg must be a pointer to a struct, usually one already filled by LoadStruct
+// with the same options. Pass the Metadata filled by that LoadStruct call
+// with WithMetadata to report where each value came from. Like LoadStruct, Dump first validates the tags of cfg
+// the same way CheckStruct does.
+//
+// Example:
+//
+//	var md envconfig.Metadata
+//	if err := envconfig.LoadStruct(&cfg, envconfig.WithMetadata(&md)); err != nil {
+//	    log.Fatal(err)
+//	}
+//	entries, err := envconfig.Dump(&cfg, envconfig.WithMetadata(&md))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	slog.Info("config loaded", "config", entries)
+func Dump(cfg any, opts ...Option) (DumpEntries, error) {
+	l := newLoader(opts)
+	l.mode = walkValue
+	specs, err := l.collectFields(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSpecs(specs); err != nil {
+		return nil, err
+	}
+
+	entries := make(DumpEntries, 0, len(specs))
+	for _, spec := range specs {
+		value := formatFormatted(spec.value, spec.format)
+		if spec.unit != "" {
+			value = formatWithUnit(spec.value, spec.unit)
+		}
+		if spec.enum != "" {
+			value = formatEnum(spec.value, spec.enum)
+		}
+		if spec.secret && value != "" {
+			value = RedactedValue
+		}
+
+		var source string
+		if l.metadata != nil {
+			if origin, ok := l.metadata.Origin(spec.path); ok {
+				source = origin.String()
+			}
+		}
+
+		entries = append(entries, DumpEntry{
+			Path:   spec.path,
+			Env:    spec.envName,
+			Value:  value,
+			Source: source,
+		})
+	}
+
+	return entries, nil
+}
+
+// WriteText writes the entries to w as an aligned table.
+func (d DumpEntries) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 1, 0, 4, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tENV\tVALUE\tSOURCE")
+	for _, e := range d {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.Path, e.Env, e.Value, e.Source)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the entries to w as an indented JSON array.
+func (d DumpEntries) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// LogValue implements slog.LogValuer. Entries are logged as attributes
+// keyed by field name, with nested structs as groups.
+func (d DumpEntries) LogValue() slog.Value {
+	root := &logGroup{}
+	for _, e := range d {
+		root.add(strings.Split(e.Path, "."), e.Value)
+	}
+	return root.value()
+}
+
+// logGroup accumulates dump entries into nested slog groups
+// while preserving field order.
+type logGroup struct {
+	names  []string
+	values map[string]string
+	groups map[string]*logGroup
+}
+
+func (g *logGroup) add(path []string, value string) {
+	name := path[0]
+	if len(path) == 1 {
+		if g.values == nil {
+			g.values = make(map[string]string)
+		}
+		g.values[name] = value
+		g.names = append(g.names, name)
+		return
+	}
+
+	if g.groups == nil {
+		g.groups = make(map[string]*logGroup)
+	}
+	sub, ok := g.groups[name]
+	if !ok {
+		sub = &logGroup{}
+		g.groups[name] = sub
+		g.names = append(g.names, name)
+	}
+	sub.add(path[1:], value)
+}
+
+func (g *logGroup) value() slog.Value {
+	attrs := make([]slog.Attr, 0, len(g.names))
+	for _, name := range g.names {
+		if sub, ok := g.groups[name]; ok {
+			attrs = append(attrs, slog.Attr{Key: name, Value: sub.value()})
+			continue
+		}
+		attrs = append(attrs, slog.String(name, g.values[name]))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// formatValue formats v in the syntax LoadStruct accepts,
+// so that slices are shown as comma-separated lists.
+// Passwords in URLs are masked.
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return ""
+	}
+
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case url.URL:
+			return x.Redacted()
+		case *url.URL:
+			return x.Redacted()
+		case os.FileMode:
+			return formatFileMode(x)
+		case encoding.TextMarshaler:
+			if text, err := x.MarshalText(); err == nil {
+				return string(text)
+			}
+		case fmt.Stringer:
+			return x.String()
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		return formatValue(v.Elem())
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(parts, ",")
+	default:
+		if !v.CanInterface() {
+			return ""
+		}
+		return fmt.Sprint(v.Interface())
+	}
+}
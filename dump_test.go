@@ -0,0 +1,145 @@
+package envconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type dumpConfig struct {
+	Host  string `env:"TEST_DUMP_HOST" default:"localhost"`
+	Ports []int  `env:"TEST_DUMP_PORTS"`
+	DB    struct {
+		User     string `env:"USER"`
+		Password string `env:"PASSWORD" secret:"true"`
+	} `prefix:"TEST_DUMP_DB_"`
+}
+
+func loadDumpConfig(t *testing.T, opts ...Option) *dumpConfig {
+	t.Helper()
+	t.Setenv("TEST_DUMP_PORTS", "8080,8081")
+	t.Setenv("TEST_DUMP_DB_USER", "admin")
+	t.Setenv("TEST_DUMP_DB_PASSWORD", "hunter2")
+
+	var cfg dumpConfig
+	if err := LoadStruct(&cfg, opts...); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	return &cfg
+}
+
+func TestDump(t *testing.T) {
+	var md Metadata
+	cfg := loadDumpConfig(t, WithMetadata(&md))
+
+	// The source is what LoadStruct recorded, not where the value would come from now.
+	t.Setenv("TEST_DUMP_HOST", "example.com")
+
+	got, err := Dump(cfg, WithMetadata(&md))
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	want := DumpEntries{
+		{Path: "Host", Env: "TEST_DUMP_HOST", Value: "localhost", Source: SourceDefault},
+		{Path: "Ports", Env: "TEST_DUMP_PORTS", Value: "8080,8081", Source: SourceEnv},
+		{Path: "DB.User", Env: "TEST_DUMP_DB_USER", Value: "admin", Source: SourceEnv},
+		{Path: "DB.Password", Env: "TEST_DUMP_DB_PASSWORD", Value: RedactedValue, Source: SourceEnv},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Dump() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Dump()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	got, err = Dump(cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	for _, e := range got {
+		if e.Source != "" {
+			t.Errorf("Dump() without metadata %s Source = %q, want empty", e.Env, e.Source)
+		}
+	}
+}
+
+func TestDumpInvalidTags(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  any
+	}{
+		{"format on string", &struct {
+			Key string `env:"TEST_DUMP_KEY" format:"base64"`
+		}{Key: "x"}},
+		{"unit on string", &struct {
+			Size string `env:"TEST_DUMP_SIZE" unit:"bytes"`
+		}{Size: "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Dump(tt.cfg)
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Errorf("Dump() error = %v, want *FieldError", err)
+			}
+		})
+	}
+
+	v := reflect.ValueOf("x")
+	if got := formatFormatted(v, FormatHex); got != "x" {
+		t.Errorf("formatFormatted(string, hex) = %q, want %q", got, "x")
+	}
+	if got := formatWithUnit(v, "bytes"); got != "x" {
+		t.Errorf("formatWithUnit(string, bytes) = %q, want %q", got, "x")
+	}
+}
+
+func TestDumpRenderers(t *testing.T) {
+	entries, err := Dump(loadDumpConfig(t))
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var sb strings.Builder
+		if err := entries.WriteText(&sb); err != nil {
+			t.Fatalf("WriteText() error = %v", err)
+		}
+		if !strings.Contains(sb.String(), "DB.User") || strings.Contains(sb.String(), "hunter2") {
+			t.Errorf("WriteText() =\n%s", sb.String())
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := entries.WriteJSON(&buf); err != nil {
+			t.Fatalf("WriteJSON() error = %v", err)
+		}
+		var decoded []DumpEntry
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		if len(decoded) != len(entries) || decoded[3].Value != RedactedValue {
+			t.Errorf("WriteJSON() = %s", buf.String())
+		}
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+		logger.Info("config", "cfg", entries)
+
+		want := `cfg.Host=localhost cfg.Ports=8080,8081 cfg.DB.User=admin cfg.DB.Password=******`
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("slog output = %q, want it to contain %q", buf.String(), want)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package envconfig
+
+import "errors"
+
+// ErrEmptyValue is returned when a variable or a list entry is empty
+// and the empty policy is EmptyIsError.
+var ErrEmptyValue = errors.New("empty value")
+
+// EmptyPolicy defines how a variable set to an empty string is treated.
+// The same policy applies to LoadStruct and to the getters, and to empty
+// entries of list values such as "1,,3".
+type EmptyPolicy int
+
+const (
+	// EmptyAsUnset treats an empty variable as if it were not set: the default
+	// value applies and a required variable is reported as missing.
+	// Empty list entries are skipped, so "1,,3" decodes to [1 3].
+	// This is the default policy.
+	EmptyAsUnset EmptyPolicy = iota
+	// EmptyAsValue treats an empty variable as a value that decodes
+	// to the zero value of the type. Empty list entries decode to zero.
+	EmptyAsValue
+	// EmptyIsError rejects empty variables and empty list entries
+	// with ErrEmptyValue.
+	EmptyIsError
+)
+
+// WithEmptyPolicy overrides the empty policy for a single call.
+// Fields tagged with notEmpty:"true" always use EmptyIsError,
+// and fields tagged with allowEmpty:"true" always use EmptyAsValue.
+//
+// Example:
+//
+//	// PORT= sets Port to 0 instead of using the default.
+//	err := envconfig.LoadStruct(&cfg, envconfig.WithEmptyPolicy(envconfig.EmptyAsValue))
+//	port, ok, err := envconfig.Lookup[int]("PORT", envconfig.WithEmptyPolicy(envconfig.EmptyIsError))
+func WithEmptyPolicy(p EmptyPolicy) Option {
+	return func(l *loader) {
+		l.empty = p
+	}
+}
+
+// decodeOptions returns the options for decoding the value of spec.
+func (l *loader) decodeOptions(spec fieldSpec) decodeOptions {
+	return spec.decodeOptions(l.emptyPolicy(spec))
+}
+
+// emptyPolicy returns the empty policy of the field, taking its tags into account.
+func (l *loader) emptyPolicy(spec fieldSpec) EmptyPolicy {
+	switch {
+	case spec.notEmpty:
+		return EmptyIsError
+	case spec.allowEmpty:
+		return EmptyAsValue
+	default:
+		return l.empty
+	}
+}
@@ -0,0 +1,106 @@
+package envconfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLoadStructEmptyPolicy(t *testing.T) {
+	type config struct {
+		Port  int    `env:"TEST_EMPTY_PORT" default:"8080"`
+		Ports []int  `env:"TEST_EMPTY_PORTS"`
+		Name  string `env:"TEST_EMPTY_NAME" default:"app"`
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    config
+		wantErr bool
+	}{
+		{
+			name: "empty as unset by default",
+			want: config{Port: 8080, Ports: []int{1, 3}, Name: "app"},
+		},
+		{
+			name: "empty as value",
+			opts: []Option{WithEmptyPolicy(EmptyAsValue)},
+			want: config{Port: 0, Ports: []int{1, 0, 3}, Name: ""},
+		},
+		{
+			name:    "empty is error",
+			opts:    []Option{WithEmptyPolicy(EmptyIsError)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_EMPTY_PORT", "")
+			t.Setenv("TEST_EMPTY_PORTS", "1,,3")
+			t.Setenv("TEST_EMPTY_NAME", "")
+
+			var cfg config
+			err := LoadStruct(&cfg, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadStruct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrEmptyValue) {
+					t.Errorf("LoadStruct() error = %v, want ErrEmptyValue", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(cfg, tt.want) {
+				t.Errorf("LoadStruct() = %+v, want %+v", cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadStructEmptyTags(t *testing.T) {
+	t.Setenv("TEST_EMPTY_TAG_PREFIX", "")
+	t.Setenv("TEST_EMPTY_TAG_TOKEN", "")
+
+	var allow struct {
+		Prefix string `env:"TEST_EMPTY_TAG_PREFIX" default:"/api" allowEmpty:"true"`
+	}
+	if err := LoadStruct(&allow); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if allow.Prefix != "" {
+		t.Errorf("Prefix = %q, want empty", allow.Prefix)
+	}
+
+	var notEmpty struct {
+		Token string `env:"TEST_EMPTY_TAG_TOKEN" default:"x" notEmpty:"true"`
+	}
+	if err := LoadStruct(&notEmpty); !errors.Is(err, ErrEmptyValue) {
+		t.Errorf("LoadStruct() error = %v, want ErrEmptyValue", err)
+	}
+
+	var required struct {
+		Token string `env:"TEST_EMPTY_TAG_TOKEN" required:"true"`
+	}
+	if err := LoadStruct(&required); err == nil {
+		t.Error("LoadStruct() error = nil, want error for empty required variable")
+	}
+}
+
+func TestLookupEmptyPolicy(t *testing.T) {
+	t.Setenv("TEST_LOOKUP_EMPTY_PORT", "")
+
+	if _, ok, err := Lookup[int]("TEST_LOOKUP_EMPTY_PORT"); ok || err != nil {
+		t.Errorf("Lookup() = _, %v, %v, want not found", ok, err)
+	}
+	if got, ok, err := Lookup[int]("TEST_LOOKUP_EMPTY_PORT", WithEmptyPolicy(EmptyAsValue)); !ok || err != nil || got != 0 {
+		t.Errorf("Lookup() = %v, %v, %v, want 0, true, nil", got, ok, err)
+	}
+	if _, _, err := Lookup[int]("TEST_LOOKUP_EMPTY_PORT", WithEmptyPolicy(EmptyIsError)); !errors.Is(err, ErrEmptyValue) {
+		t.Errorf("Lookup() error = %v, want ErrEmptyValue", err)
+	}
+	if got := GetAs("TEST_LOOKUP_EMPTY_PORT", 8080, WithEmptyPolicy(EmptyAsValue)); got != 0 {
+		t.Errorf("GetAs() = %v, want 0", got)
+	}
+}
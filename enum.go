@@ -0,0 +1,259 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = make(map[reflect.Type]func(value string) (reflect.Value, error))
+)
+
+// RegisterParser registers parse as the decoder of values of type T for
+// LoadStruct, Lookup and the getters. It takes precedence over the built-in
+// decoders, so it also serves the ParseMode(string) convention of enum types:
+//
+//	func init() {
+//	    envconfig.RegisterParser(ParseMode)
+//	}
+//
+// An empty value sets the zero value without calling parse.
+// Registering a parser for the same type again replaces it.
+func RegisterParser[T any](parse func(value string) (T, error)) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[t] = func(value string) (reflect.Value, error) {
+		v, err := parse(value)
+		return reflect.ValueOf(&v).Elem(), err
+	}
+}
+
+// lookupParser returns the parser registered for t, if any.
+func lookupParser(t reflect.Type) (func(value string) (reflect.Value, error), bool) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	parse, ok := parsers[t]
+	return parse, ok
+}
+
+// setParsed decodes value with a parser registered by RegisterParser.
+func setParsed(field reflect.Value, value string, parse func(value string) (reflect.Value, error)) error {
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	v, err := parse(value)
+	if err != nil {
+		return err
+	}
+	field.Set(v)
+	return nil
+}
+
+// enumValue is a name and value from the "enum" tag.
+type enumValue struct {
+	name  string
+	value string
+}
+
+// parseEnumTag parses an "enum" tag such as "fast=1,safe=2,off=0".
+// A name without "=" stands for itself, e.g. "json,text" for a string field.
+func parseEnumTag(tag string) []enumValue {
+	var values []enumValue
+	for _, item := range strings.Split(tag, ",") {
+		name, value, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			value = name
+		}
+		values = append(values, enumValue{name: name, value: strings.TrimSpace(value)})
+	}
+	return values
+}
+
+// setEnum decodes value into field by the names listed in the "enum" tag.
+// Names are compared case-insensitively; any other value is an error
+// listing the allowed names.
+func setEnum(field reflect.Value, value string, opts decodeOptions) error {
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !isLeafType(field.Type()) {
+			return setSliceOrArray(field, value, opts)
+		}
+	}
+
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	values := parseEnumTag(opts.enum)
+	for _, v := range values {
+		if strings.EqualFold(v.name, value) {
+			return setValue(field, v.value, decodeOptions{empty: opts.empty})
+		}
+	}
+
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.name
+	}
+	return enumError(value, names)
+}
+
+// enumElemType returns the type the names of the "enum" tag of a field of
+// type t decode into: t itself, or its element type for lists.
+func enumElemType(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !isLeafType(t) {
+			return t.Elem()
+		}
+	}
+	return t
+}
+
+// enumError reports a value that is not one of the allowed names.
+func enumError(value string, names []string) error {
+	return fmt.Errorf("invalid value %q (allowed: %s)", value, strings.Join(names, ", "))
+}
+
+// formatEnum returns the name of v in the "enum" tag, or formats v as usual
+// if it has none.
+func formatEnum(v reflect.Value, tag string) string {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatEnum(v.Index(i), tag)
+		}
+		return strings.Join(parts, ",")
+	}
+
+	raw := formatValue(v)
+	for _, e := range parseEnumTag(tag) {
+		if e.value == raw {
+			return e.name
+		}
+	}
+	return raw
+}
+
+// maxStringerScan is the number of values setStringer tries when looking
+// for an integer whose String method returns the given name.
+const maxStringerScan = 1024
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// isStringerEnum reports whether t is an integer type with a String method,
+// such as the types generated by the stringer tool.
+func isStringerEnum(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return t.Implements(stringerType)
+	}
+	return false
+}
+
+// setStringer decodes a name into an integer enum type by finding the value,
+// from 0 up to maxStringerScan, whose String method returns the name.
+// The scan stops at the first value after 0 without a name of its own,
+// see stringerName. Names are compared case-insensitively. Numbers are
+// accepted as well.
+func setStringer(field reflect.Value, value string, _ decodeOptions) error {
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if digits := strings.TrimLeft(value, "+-"); digits != "" && digits[0] >= '0' && digits[0] <= '9' {
+		return setStringerNumber(field, value)
+	}
+
+	var names []string
+	var values []reflect.Value
+	seen := make(map[string]bool)
+	for i := 0; i < maxStringerScan; i++ {
+		candidate := reflect.New(field.Type()).Elem()
+		if candidate.CanUint() {
+			if candidate.OverflowUint(uint64(i)) {
+				break
+			}
+			candidate.SetUint(uint64(i))
+		} else {
+			if candidate.OverflowInt(int64(i)) {
+				break
+			}
+			candidate.SetInt(int64(i))
+		}
+
+		name, ok := stringerName(candidate, i)
+		if ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+			values = append(values, candidate)
+			continue
+		}
+		// Enums often leave 0 unnamed, e.g. time.Month
+		if i == 0 {
+			continue
+		}
+		// A name repeated past the last one, such as "unknown", is a
+		// placeholder for values without a name, and so is the same name of 0.
+		if ok && len(names) > 0 && names[0] == name && values[0].IsZero() {
+			names, values = names[1:], values[1:]
+		}
+		break
+	}
+
+	for i, name := range names {
+		if strings.EqualFold(name, value) {
+			field.Set(values[i])
+			return nil
+		}
+	}
+
+	return enumError(value, names)
+}
+
+// stringerName returns the result of the String method of v, which holds i,
+// and whether it is a name: String must not panic, as an index into a slice
+// of names does for values past its end, and must not return an empty string,
+// the number itself or a placeholder such as Mode(5) (stringer) or %!Mode(5) (fmt).
+func stringerName(v reflect.Value, i int) (name string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			name, ok = "", false
+		}
+	}()
+	name = v.Interface().(fmt.Stringer).String()
+	if name == "" || name == strconv.Itoa(i) || strings.Contains(name, "(") || strings.Contains(name, "%!") {
+		return name, false
+	}
+	return name, true
+}
+
+// setStringerNumber decodes a number into an integer enum type.
+// It must not go through setValue, which would pass the value back to setStringer.
+func setStringerNumber(field reflect.Value, value string) error {
+	if field.CanUint() {
+		v, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+		return nil
+	}
+	v, err := strconv.ParseInt(value, 10, field.Type().Bits())
+	if err != nil {
+		return err
+	}
+	field.SetInt(v)
+	return nil
+}
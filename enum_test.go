@@ -0,0 +1,250 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mode int
+
+type level uint8
+
+const (
+	levelLow level = iota
+	levelMid
+	levelHigh
+)
+
+func (l level) String() string {
+	switch l {
+	case levelLow:
+		return "low"
+	case levelMid:
+		return "mid"
+	case levelHigh:
+		return "high"
+	}
+	return fmt.Sprintf("level(%d)", uint8(l))
+}
+
+// shade indexes its names like most hand-written String methods,
+// so String panics for values without a name.
+type shade int
+
+var shadeNames = [...]string{"light", "dark"}
+
+func (s shade) String() string { return shadeNames[s] }
+
+// speed returns "unknown" for every value without a name.
+type speed int
+
+func (s speed) String() string {
+	switch s {
+	case 1:
+		return "slow"
+	case 2:
+		return "fast"
+	}
+	return "unknown"
+}
+
+type color struct{ r, g, b uint8 }
+
+func parseColor(s string) (color, error) {
+	switch s {
+	case "red":
+		return color{r: 255}, nil
+	case "blue":
+		return color{b: 255}, nil
+	}
+	return color{}, errors.New("unknown color")
+}
+
+func (c color) String() string {
+	if c.r == 255 {
+		return "red"
+	}
+	return "blue"
+}
+
+type enumConfig struct {
+	Mode   mode       `env:"ENUM_MODE" enum:"fast=1,safe=2,off=0" default:"safe"`
+	Modes  []mode     `env:"ENUM_MODES" enum:"fast=1,safe=2,off=0"`
+	Format string     `env:"ENUM_FORMAT" enum:"json,text" default:"text"`
+	Level  level      `env:"ENUM_LEVEL"`
+	Month  time.Month `env:"ENUM_MONTH"`
+	Color  color      `env:"ENUM_COLOR" default:"red"`
+}
+
+func TestLoadStructEnum(t *testing.T) {
+	RegisterParser(parseColor)
+
+	t.Setenv("ENUM_MODE", "Fast")
+	t.Setenv("ENUM_MODES", "off,safe")
+	t.Setenv("ENUM_FORMAT", "json")
+	t.Setenv("ENUM_LEVEL", "HIGH")
+	t.Setenv("ENUM_MONTH", "march")
+	t.Setenv("ENUM_COLOR", "blue")
+
+	var cfg enumConfig
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.Mode != 1 || len(cfg.Modes) != 2 || cfg.Modes[0] != 0 || cfg.Modes[1] != 2 {
+		t.Errorf("Mode = %v, Modes = %v", cfg.Mode, cfg.Modes)
+	}
+	if cfg.Format != "json" || cfg.Level != levelHigh || cfg.Month != time.March || cfg.Color != (color{b: 255}) {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+
+	entries, err := Dump(&cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	want := []string{"fast", "off,safe", "json", "high", "March", "blue"}
+	for i, e := range entries {
+		if e.Value != want[i] {
+			t.Errorf("Dump() %s = %q, want %q", e.Env, e.Value, want[i])
+		}
+	}
+}
+
+func TestLoadStructEnumErrors(t *testing.T) {
+	RegisterParser(parseColor)
+
+	tests := []struct {
+		key     string
+		value   string
+		wantErr string
+	}{
+		{"ENUM_MODE", "turbo", `env ENUM_MODE: invalid value "turbo" (allowed: fast, safe, off)`},
+		{"ENUM_MODES", "fast,slow", `env ENUM_MODES: invalid envconfig.mode value at index 1: invalid value "slow" (allowed: fast, safe, off)`},
+		{"ENUM_FORMAT", "xml", `env ENUM_FORMAT: invalid value "xml" (allowed: json, text)`},
+		{"ENUM_LEVEL", "max", `env ENUM_LEVEL: invalid value "max" (allowed: low, mid, high)`},
+		{"ENUM_LEVEL", "300", `env ENUM_LEVEL: strconv.ParseUint: parsing "300": value out of range`},
+		{"ENUM_MONTH", "Smarch", `env ENUM_MONTH: invalid value "Smarch" (allowed: January, February, March, April, May, June, July, August, September, October, November, December)`},
+		{"ENUM_COLOR", "green", "env ENUM_COLOR: unknown color"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+
+			var cfg enumConfig
+			if err := LoadStruct(&cfg); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("LoadStruct() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadStructStringerNumber(t *testing.T) {
+	t.Setenv("ENUM_LEVEL", "1")
+	t.Setenv("ENUM_MONTH", "3")
+	t.Setenv("ENUM_NUMBER_MONTH", "12")
+
+	var cfg enumConfig
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if cfg.Level != levelMid || cfg.Month != time.March {
+		t.Errorf("Level = %v, Month = %v", cfg.Level, cfg.Month)
+	}
+
+	if got := GetAs("ENUM_NUMBER_MONTH", time.March); got != time.December {
+		t.Errorf("GetAs() = %v, want December", got)
+	}
+}
+
+func TestLoadStructStringerWithoutNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		cfg     any
+		wantErr string
+	}{
+		{
+			name:  "panicking String",
+			value: "bogus",
+			cfg: &struct {
+				Shade shade `env:"ENUM_UNNAMED"`
+			}{},
+			wantErr: `env ENUM_UNNAMED: invalid value "bogus" (allowed: light, dark)`,
+		},
+		{
+			name:  "repeated placeholder",
+			value: "bogus",
+			cfg: &struct {
+				Speed speed `env:"ENUM_UNNAMED"`
+			}{},
+			wantErr: `env ENUM_UNNAMED: invalid value "bogus" (allowed: slow, fast)`,
+		},
+		{
+			name:  "placeholder is not a name",
+			value: "unknown",
+			cfg: &struct {
+				Speed speed `env:"ENUM_UNNAMED"`
+			}{},
+			wantErr: `env ENUM_UNNAMED: invalid value "unknown" (allowed: slow, fast)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENUM_UNNAMED", tt.value)
+			if err := LoadStruct(tt.cfg); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("LoadStruct() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Setenv("ENUM_UNNAMED", "Dark")
+	var cfg struct {
+		Shade shade `env:"ENUM_UNNAMED"`
+	}
+	if err := LoadStruct(&cfg); err != nil || cfg.Shade != 1 {
+		t.Errorf("LoadStruct() = %v, %v, want dark", cfg.Shade, err)
+	}
+}
+
+func TestLoadStructEnumTagOnStringer(t *testing.T) {
+	t.Setenv("ENUM_TAGGED_LEVEL", "top")
+
+	var cfg struct {
+		Level level `env:"ENUM_TAGGED_LEVEL" enum:"bottom=0,top=2"`
+	}
+	if err := CheckStruct(&cfg); err != nil {
+		t.Fatalf("CheckStruct() error = %v", err)
+	}
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if cfg.Level != levelHigh {
+		t.Errorf("Level = %v, want high", cfg.Level)
+	}
+}
+
+func TestLookupRegisteredParser(t *testing.T) {
+	RegisterParser(parseColor)
+	t.Setenv("ENUM_LOOKUP_COLOR", "red")
+
+	got, ok, err := Lookup[color]("ENUM_LOOKUP_COLOR")
+	if err != nil || !ok || got != (color{r: 255}) {
+		t.Errorf("Lookup() = %v, %v, %v", got, ok, err)
+	}
+}
+
+func TestCheckStructEnum(t *testing.T) {
+	var cfg struct {
+		Mode mode `env:"ENUM_CHECK_MODE" enum:"fast=1,safe=x"`
+	}
+
+	err := CheckStruct(&cfg)
+	if err == nil || !strings.Contains(err.Error(), "invalid enum value safe=x") {
+		t.Errorf("CheckStruct() error = %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package envconfig
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteExample writes a commented .env template for cfg to w.
+// cfg must be a pointer to a struct.
+//
+// Each variable is preceded by its "desc" tag as a comment and is assigned
+// its default value. Required variables are marked with a "required" comment.
+// Fields tagged with secret:"true" are always left blank.
+// Fields of nested structs are grouped under a section header.
+// Slices of structs are shown with a single element at index 0.
+// Options that affect variable names, such as WithAutoNames, are honoured.
+//
+// Example output:
+//
+//	# listen address
+//	HOST=localhost
+//
+//	# required
+//	DSN=
+//
+//	# --- DB (DB_) ---
+//
+//	# secret
+//	DB_PASSWORD=
+func WriteExample(w io.Writer, cfg any, opts ...Option) error {
+	specs, err := newLoader(opts).collectFields(cfg)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	// Top-level fields go first, nested structs follow in declaration order.
+	var groups []string
+	byGroup := make(map[string][]fieldSpec)
+	for _, spec := range specs {
+		if _, ok := byGroup[spec.group]; !ok && spec.group != "" {
+			groups = append(groups, spec.group)
+		}
+		byGroup[spec.group] = append(byGroup[spec.group], spec)
+	}
+
+	first := true
+	writeSpecs := func(specs []fieldSpec) {
+		for _, spec := range specs {
+			if !first {
+				bw.WriteString("\n")
+			}
+			first = false
+			writeExampleVar(bw, spec)
+		}
+	}
+
+	writeSpecs(byGroup[""])
+	for _, group := range groups {
+		if !first {
+			bw.WriteString("\n")
+		}
+		first = true
+
+		header := group
+		if prefix := byGroup[group][0].prefix; prefix != "" {
+			header = fmt.Sprintf("%s (%s)", group, prefix)
+		}
+		fmt.Fprintf(bw, "# --- %s ---\n\n", header)
+		writeSpecs(byGroup[group])
+	}
+
+	return bw.Flush()
+}
+
+// writeExampleVar writes a single commented assignment.
+func writeExampleVar(w *bufio.Writer, spec fieldSpec) {
+	for _, line := range strings.Split(spec.desc, "\n") {
+		if line != "" {
+			fmt.Fprintf(w, "# %s\n", line)
+		}
+	}
+	if spec.required {
+		w.WriteString("# required\n")
+	}
+
+	value := spec.defaultValue
+	if spec.secret {
+		w.WriteString("# secret\n")
+		value = ""
+	}
+
+	fmt.Fprintf(w, "%s=%s\n", spec.envName, quoteEnvValue(value))
+}
+
+// quoteEnvValue returns value in a form that reads back unchanged from a .env file.
+func quoteEnvValue(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n#\"'\\$`") {
+		return value
+	}
+
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"$", `\$`,
+	)
+	return `"` + r.Replace(value) + `"`
+}
@@ -0,0 +1,62 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteExample(t *testing.T) {
+	var cfg struct {
+		Host string `env:"HOST" default:"localhost" desc:"listen address"`
+		DB   struct {
+			Host     string `env:"HOST" default:"db.local"`
+			Password string `env:"PASSWORD" default:"hunter2" secret:"true"`
+		} `prefix:"DB_"`
+		DSN      string `env:"DSN" required:"true"`
+		Greeting string `env:"GREETING" default:"hello world # not a comment"`
+	}
+
+	var sb strings.Builder
+	if err := WriteExample(&sb, &cfg); err != nil {
+		t.Fatalf("WriteExample() error = %v", err)
+	}
+
+	want := `# listen address
+HOST=localhost
+
+# required
+DSN=
+
+GREETING="hello world # not a comment"
+
+# --- DB (DB_) ---
+
+DB_HOST=db.local
+
+# secret
+DB_PASSWORD=
+`
+	if sb.String() != want {
+		t.Errorf("WriteExample() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
+
+func TestWriteExampleRoundTrip(t *testing.T) {
+	var cfg struct {
+		Value string `env:"VALUE" default:"a \"quoted\" \\ value"`
+	}
+
+	var sb strings.Builder
+	if err := WriteExample(&sb, &cfg); err != nil {
+		t.Fatalf("WriteExample() error = %v", err)
+	}
+
+	file, err := ParseEnv(strings.NewReader(sb.String()), ".env.example")
+	if err != nil {
+		t.Fatalf("ParseEnv() error = %v", err)
+	}
+	got := file.Vars()
+	if got["VALUE"] != `a "quoted" \ value` {
+		t.Errorf("VALUE = %q, want %q", got["VALUE"], `a "quoted" \ value`)
+	}
+}
@@ -0,0 +1,214 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldSpec describes a struct field that is mapped to an environment variable.
+type fieldSpec struct {
+	value reflect.Value
+	field reflect.StructField
+
+	// path is the Go path of the field, e.g. "DB.Host".
+	path string
+	// group is the Go path of the nested struct containing the field,
+	// e.g. "DB". It is empty for top-level fields.
+	group string
+	// prefix is the accumulated "prefix" tag of the enclosing structs.
+	prefix string
+
+	envName string
+	// aliases are the old names of the variable, with the prefix applied.
+	aliases []string
+	// deprecated is the "deprecated" tag: "true" or a message,
+	// or "" if the field is not deprecated.
+	deprecated   string
+	defaultValue string
+	hasDefault   bool
+	required     bool
+	secret       bool
+	notEmpty     bool
+	allowEmpty   bool
+	unquote      bool
+	// format is the "format" tag, e.g. "json".
+	format string
+	// schemes is the "schemes" tag of a URL field.
+	schemes []string
+	// unit is the "unit" tag, e.g. "bytes".
+	unit string
+	// enum is the "enum" tag, e.g. "fast=1,safe=2,off=0".
+	enum string
+	// transform is the "transform" tag, e.g. "trim,lower".
+	transform string
+	desc      string
+}
+
+// collectFields returns the specs of all fields of cfg that have an "env" tag,
+// or all exported fields if automatic naming is enabled.
+// cfg must be a pointer to a struct.
+//
+// Struct fields without an "env" tag are treated as nested configuration:
+// their fields are collected too, with the nested struct's "prefix" tag
+// prepended to their environment variable names. Slices of such structs
+// and maps of them with string keys are expanded according to the walk mode
+// of the loader.
+// Fields tagged with env:"-" are always skipped.
+func (l *loader) collectFields(cfg any) ([]fieldSpec, error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cfg must be pointer to struct")
+	}
+
+	var specs []fieldSpec
+	if err := l.walkFields(v.Elem(), "", l.prefix, &specs); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+// walkFields appends the specs of the fields of the struct v to specs.
+func (l *loader) walkFields(v reflect.Value, path, prefix string, specs *[]fieldSpec) error {
+	t := v.Type()
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+
+		fieldPath := fieldType.Name
+		if path != "" {
+			fieldPath = path + "." + fieldType.Name
+		}
+
+		envName := fieldType.Tag.Get("env")
+		if envName == "-" {
+			continue
+		}
+		if envName == "" && fieldType.Tag.Get("format") == "" {
+			if isNestedStruct(field.Type()) {
+				err := l.walkFields(field, fieldPath, prefix+l.nestedPrefix(fieldType), specs)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if field.Kind() == reflect.Slice && isNestedStruct(field.Type().Elem()) {
+				err := l.walkSlice(field, fieldPath, prefix+l.nestedPrefix(fieldType), specs)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if field.Kind() == reflect.Map && field.Type().Key().Kind() == reflect.String && isNestedStruct(field.Type().Elem()) {
+				err := l.walkMap(field, fieldType, fieldPath, prefix+l.nestedPrefix(fieldType), specs)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if envName == "" {
+			if l.naming == nil || !fieldType.IsExported() {
+				continue
+			}
+			envName = l.naming(fieldType.Name)
+		}
+
+		var aliases []string
+		if tag := fieldType.Tag.Get("aliases"); tag != "" {
+			for _, alias := range strings.Split(tag, ",") {
+				aliases = append(aliases, prefix+strings.TrimSpace(alias))
+			}
+		}
+
+		var schemes []string
+		if tag := fieldType.Tag.Get("schemes"); tag != "" {
+			for _, scheme := range strings.Split(tag, ",") {
+				schemes = append(schemes, strings.TrimSpace(scheme))
+			}
+		}
+
+		defaultValue, hasDefault := fieldType.Tag.Lookup("default")
+		*specs = append(*specs, fieldSpec{
+			value:        field,
+			field:        fieldType,
+			path:         fieldPath,
+			group:        path,
+			prefix:       prefix,
+			envName:      prefix + envName,
+			aliases:      aliases,
+			deprecated:   deprecatedTag(fieldType.Tag.Get("deprecated")),
+			defaultValue: defaultValue,
+			hasDefault:   hasDefault,
+			required:     isTrue(fieldType.Tag.Get("required")),
+			secret:       isTrue(fieldType.Tag.Get("secret")),
+			notEmpty:     isTrue(fieldType.Tag.Get("notEmpty")),
+			allowEmpty:   isTrue(fieldType.Tag.Get("allowEmpty")),
+			unquote:      isTrue(fieldType.Tag.Get("unquote")),
+			format:       fieldType.Tag.Get("format"),
+			schemes:      schemes,
+			unit:         fieldType.Tag.Get("unit"),
+			enum:         fieldType.Tag.Get("enum"),
+			transform:    fieldType.Tag.Get("transform"),
+			desc:         fieldType.Tag.Get("desc"),
+		})
+	}
+
+	return nil
+}
+
+// nestedPrefix returns the prefix for the fields of a nested struct or
+// slice or map of structs: its "prefix" tag, or with automatic naming the derived
+// name of the field followed by "_".
+func (l *loader) nestedPrefix(fieldType reflect.StructField) string {
+	prefix, ok := fieldType.Tag.Lookup("prefix")
+	if !ok && l.naming != nil && !fieldType.Anonymous {
+		prefix = l.naming(fieldType.Name) + "_"
+	}
+	return prefix
+}
+
+// isNestedStruct reports whether t is a struct loaded as nested configuration.
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isLeafType(t)
+}
+
+// decodeOptions returns the options for decoding the value of the field
+// under the given empty policy.
+func (s fieldSpec) decodeOptions(empty EmptyPolicy) decodeOptions {
+	return decodeOptions{empty: empty, format: s.format, schemes: s.schemes, unit: s.unit, enum: s.enum, secret: s.secret}
+}
+
+// names returns the variable name of the field followed by its aliases.
+func (s fieldSpec) names() []string {
+	return append([]string{s.envName}, s.aliases...)
+}
+
+// isLeafType reports whether a struct, slice or array type is decoded from
+// a single variable rather than loaded as nested configuration or a list.
+func isLeafType(t reflect.Type) bool {
+	if _, ok := typeDecoders[t]; ok {
+		return true
+	}
+	if _, ok := lookupParser(t); ok {
+		return true
+	}
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// isTrue reports whether a boolean struct tag value is set to true.
+// deprecatedTag returns the "deprecated" tag, or "" if it is a false
+// boolean such as "false", which marks the variable as not deprecated.
+func deprecatedTag(tag string) string {
+	if v, err := strconv.ParseBool(tag); err == nil && !v {
+		return ""
+	}
+	return tag
+}
+
+func isTrue(tag string) bool {
+	v, _ := strconv.ParseBool(tag)
+	return v
+}
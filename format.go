@@ -0,0 +1,113 @@
+package envconfig
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Values of the "format" tag.
+const (
+	// FormatJSON decodes the value with encoding/json into any field type.
+	FormatJSON = "json"
+	// FormatBase64 decodes standard or URL-safe base64, padded or not, into []byte.
+	FormatBase64 = "base64"
+	// FormatHex decodes hexadecimal into []byte.
+	FormatHex = "hex"
+)
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// setFormatted decodes value into field according to the "format" tag.
+// An empty value sets the zero value.
+func setFormatted(field reflect.Value, value, format string) error {
+	switch format {
+	case FormatJSON:
+	case FormatBase64, FormatHex:
+		if field.Type() != bytesType {
+			return fmt.Errorf("format %q requires []byte, got %s", format, field.Type())
+		}
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	switch format {
+	case FormatJSON:
+		// Decode into a new value so that the field is unchanged on error.
+		target := reflect.New(field.Type())
+		if err := json.Unmarshal([]byte(value), target.Interface()); err != nil {
+			return jsonError(err)
+		}
+		field.Set(target.Elem())
+
+	case FormatBase64:
+		encoding := base64.RawStdEncoding
+		if strings.ContainsAny(value, "-_") {
+			encoding = base64.RawURLEncoding
+		}
+		data, err := encoding.DecodeString(strings.TrimRight(value, "="))
+		if err != nil {
+			return fmt.Errorf("invalid base64: %w", err)
+		}
+		field.SetBytes(data)
+
+	case FormatHex:
+		data, err := hex.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("invalid hex: %w", err)
+		}
+		field.SetBytes(data)
+	}
+
+	return nil
+}
+
+// jsonError adds the offset of the error in the value to JSON decoding errors.
+func jsonError(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("invalid JSON at offset %d: %w", syntaxErr.Offset, err)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("invalid JSON at offset %d: %w", typeErr.Offset, err)
+	}
+	return fmt.Errorf("invalid JSON: %w", err)
+}
+
+// formatFormatted formats v in the syntax of the "format" tag.
+func formatFormatted(v reflect.Value, format string) string {
+	switch format {
+	case FormatJSON:
+		if !v.CanInterface() {
+			return ""
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v.Interface()); err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(buf.String(), "\n")
+	case FormatBase64, FormatHex:
+		if v.Type() != bytesType {
+			return formatValue(v)
+		}
+		if format == FormatHex {
+			return hex.EncodeToString(v.Bytes())
+		}
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	default:
+		return formatValue(v)
+	}
+}
@@ -0,0 +1,145 @@
+package envconfig
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type route struct {
+	Path    string `json:"path"`
+	Backend string `json:"backend"`
+}
+
+type formatConfig struct {
+	Routes   []route         `env:"FMT_ROUTES" format:"json"`
+	Features map[string]bool `env:"FMT_FEATURES" format:"json" default:"{\"beta\":true}"`
+	Key      []byte          `env:"FMT_KEY" format:"base64"`
+	Salt     []byte          `env:"FMT_SALT" format:"hex"`
+}
+
+func TestLoadStructFormat(t *testing.T) {
+	t.Setenv("FMT_ROUTES", `[{"path":"/api","backend":"api:8080"},{"path":"/","backend":"web:80"}]`)
+	t.Setenv("FMT_KEY", "aGVsbG8=")
+	t.Setenv("FMT_SALT", "deadbeef")
+
+	var cfg formatConfig
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if len(cfg.Routes) != 2 || cfg.Routes[1] != (route{Path: "/", Backend: "web:80"}) {
+		t.Errorf("Routes = %+v", cfg.Routes)
+	}
+	if !cfg.Features["beta"] {
+		t.Errorf("Features = %v, want beta from default", cfg.Features)
+	}
+	if string(cfg.Key) != "hello" {
+		t.Errorf("Key = %q, want %q", cfg.Key, "hello")
+	}
+	if !bytes.Equal(cfg.Salt, []byte{0xde, 0xad, 0xbe, 0xef}) {
+		t.Errorf("Salt = %x, want deadbeef", cfg.Salt)
+	}
+}
+
+func TestSetFormatted(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "base64 padded", format: FormatBase64, value: "aGk=", want: "hi"},
+		{name: "base64 unpadded", format: FormatBase64, value: "aGk", want: "hi"},
+		{name: "base64 url-safe", format: FormatBase64, value: "-_8", want: "\xfb\xff"},
+		{name: "base64 invalid", format: FormatBase64, value: "a$b", wantErr: "invalid base64"},
+		{name: "hex", format: FormatHex, value: "6869", want: "hi"},
+		{name: "hex invalid", format: FormatHex, value: "zz", wantErr: "invalid hex"},
+		{name: "empty", format: FormatHex, value: "", want: ""},
+		{name: "unknown format", format: "yaml", value: "x", wantErr: `unknown format "yaml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []byte
+			err := setValue(reflect.ValueOf(&got).Elem(), tt.value, decodeOptions{format: tt.format})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("setValue() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setValue() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("setValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadStructFormatErrors(t *testing.T) {
+	t.Setenv("FMT_ROUTES", `[{"path":"/api",}]`)
+
+	var cfg formatConfig
+	err := LoadStruct(&cfg)
+	want := "env FMT_ROUTES: invalid JSON at offset 17: invalid character '}'"
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Fatalf("LoadStruct() error = %v, want %q", err, want)
+	}
+	if cfg.Routes != nil {
+		t.Errorf("Routes = %+v, want unchanged", cfg.Routes)
+	}
+
+	t.Setenv("FMT_ROUTES", `{"path":"/"}`)
+	if err := LoadStruct(&cfg); err == nil || !strings.Contains(err.Error(), "invalid JSON at offset") {
+		t.Errorf("LoadStruct() error = %v, want type error with offset", err)
+	}
+}
+
+func TestCheckStructFormat(t *testing.T) {
+	var cfg struct {
+		Port int    `env:"FMT_PORT" format:"base64"`
+		Mode string `env:"FMT_MODE" format:"xml"`
+	}
+
+	err := CheckStruct(&cfg)
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("CheckStruct() error = %v, want *FieldError", err)
+	}
+	for _, want := range []string{`format "base64" requires []byte, got int`, `unknown format "xml"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("CheckStruct() error = %v, want %q", err, want)
+		}
+	}
+}
+
+func TestDumpFormat(t *testing.T) {
+	cfg := formatConfig{
+		Routes: []route{{Path: "/", Backend: "web:80"}},
+		Key:    []byte("hello"),
+		Salt:   []byte{0xde, 0xad},
+	}
+
+	entries, err := Dump(&cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	want := map[string]string{
+		"FMT_ROUTES":   `[{"path":"/","backend":"web:80"}]`,
+		"FMT_FEATURES": "null",
+		"FMT_KEY":      "aGVsbG8=",
+		"FMT_SALT":     "dead",
+	}
+	for _, e := range entries {
+		if e.Value != want[e.Env] {
+			t.Errorf("Dump() %s = %q, want %q", e.Env, e.Value, want[e.Env])
+		}
+	}
+}
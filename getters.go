@@ -0,0 +1,144 @@
+package envconfig
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ParseError reports an environment variable whose value cannot be decoded.
+// It is returned by Lookup and the *E getters and passed to the fallback hook.
+type ParseError struct {
+	// Key is the name of the environment variable.
+	Key string
+	// Value is the raw value of the variable.
+	Value string
+	// Type is the Go type the value was decoded into, e.g. "int".
+	Type string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("env %s: invalid %s value: %v", e.Key, e.Type, e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+var fallbackHook atomic.Pointer[func(err *ParseError)]
+
+// SetFallbackHook registers fn to be called whenever GetAs or one of the Get*
+// functions returns its default value because the variable could not be parsed,
+// so that PORT=80a silently becoming 8080 is at least logged. Pass nil to remove
+// the hook. It is safe to call concurrently with the getters.
+//
+// Example:
+//
+//	envconfig.SetFallbackHook(func(err *envconfig.ParseError) {
+//	    slog.Warn("using default value", "err", err)
+//	})
+func SetFallbackHook(fn func(err *ParseError)) {
+	if fn == nil {
+		fallbackHook.Store(nil)
+		return
+	}
+	fallbackHook.Store(&fn)
+}
+
+// notifyFallback calls the fallback hook, if any.
+func notifyFallback(err *ParseError) {
+	if fn := fallbackHook.Load(); fn != nil {
+		(*fn)(err)
+	}
+}
+
+// GetAsE is like GetAs, but returns a *ParseError together with the default
+// value if the variable cannot be parsed.
+func GetAsE[T any](key string, defaultValue T, opts ...Option) (T, error) {
+	value, ok, err := Lookup[T](key, opts...)
+	if err != nil {
+		return defaultValue, err
+	}
+	if !ok {
+		return defaultValue, nil
+	}
+	return value, nil
+}
+
+// MustGetAs is like GetAs, but panics if the variable cannot be parsed.
+func MustGetAs[T any](key string, defaultValue T, opts ...Option) T {
+	value, err := GetAsE(key, defaultValue, opts...)
+	if err != nil {
+		panic("envconfig: " + err.Error())
+	}
+	return value
+}
+
+// GetBoolE is like GetBool, but returns a *ParseError if the variable cannot be parsed.
+func GetBoolE(key string, defaultValue bool) (bool, error) {
+	return GetAsE(key, defaultValue)
+}
+
+// GetIntE is like GetInt, but returns a *ParseError if the variable cannot be parsed.
+func GetIntE(key string, defaultValue int) (int, error) {
+	return GetAsE(key, defaultValue)
+}
+
+// GetInt64E is like GetInt64, but returns a *ParseError if the variable cannot be parsed.
+func GetInt64E(key string, defaultValue int64) (int64, error) {
+	return GetAsE(key, defaultValue)
+}
+
+// GetFloat64E is like GetFloat64, but returns a *ParseError if the variable cannot be parsed.
+func GetFloat64E(key string, defaultValue float64) (float64, error) {
+	return GetAsE(key, defaultValue)
+}
+
+// GetDurationE is like GetDuration, but returns a *ParseError if the variable cannot be parsed.
+func GetDurationE(key string, defaultValue time.Duration) (time.Duration, error) {
+	return GetAsE(key, defaultValue)
+}
+
+// GetIntSliceE is like GetIntSlice, but returns a *ParseError if the variable cannot be parsed.
+func GetIntSliceE(key string, defaultValue []int) ([]int, error) {
+	return GetAsE(key, defaultValue)
+}
+
+// GetInt64SliceE is like GetInt64Slice, but returns a *ParseError if the variable cannot be parsed.
+func GetInt64SliceE(key string, defaultValue []int64) ([]int64, error) {
+	return GetAsE(key, defaultValue)
+}
+
+// MustGetBool is like GetBool, but panics if the variable cannot be parsed.
+func MustGetBool(key string, defaultValue bool) bool {
+	return MustGetAs(key, defaultValue)
+}
+
+// MustGetInt is like GetInt, but panics if the variable cannot be parsed.
+func MustGetInt(key string, defaultValue int) int {
+	return MustGetAs(key, defaultValue)
+}
+
+// MustGetInt64 is like GetInt64, but panics if the variable cannot be parsed.
+func MustGetInt64(key string, defaultValue int64) int64 {
+	return MustGetAs(key, defaultValue)
+}
+
+// MustGetFloat64 is like GetFloat64, but panics if the variable cannot be parsed.
+func MustGetFloat64(key string, defaultValue float64) float64 {
+	return MustGetAs(key, defaultValue)
+}
+
+// MustGetDuration is like GetDuration, but panics if the variable cannot be parsed.
+func MustGetDuration(key string, defaultValue time.Duration) time.Duration {
+	return MustGetAs(key, defaultValue)
+}
+
+// MustGetIntSlice is like GetIntSlice, but panics if the variable cannot be parsed.
+func MustGetIntSlice(key string, defaultValue []int) []int {
+	return MustGetAs(key, defaultValue)
+}
+
+// MustGetInt64Slice is like GetInt64Slice, but panics if the variable cannot be parsed.
+func MustGetInt64Slice(key string, defaultValue []int64) []int64 {
+	return MustGetAs(key, defaultValue)
+}
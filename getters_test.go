@@ -0,0 +1,118 @@
+package envconfig
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGetIntE(t *testing.T) {
+	t.Setenv("TEST_INTE_VALID", "9000")
+	t.Setenv("TEST_INTE_INVALID", "80a")
+
+	tests := []struct {
+		name    string
+		key     string
+		want    int
+		wantErr bool
+	}{
+		{name: "valid value", key: "TEST_INTE_VALID", want: 9000},
+		{name: "missing value", key: "TEST_INTE_MISSING", want: 8080},
+		{name: "invalid value", key: "TEST_INTE_INVALID", want: 8080, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetIntE(tt.key, 8080)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetIntE() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetIntE() = %v, want %v", got, tt.want)
+			}
+			if err == nil {
+				return
+			}
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("GetIntE() error = %T, want *ParseError", err)
+			}
+			if parseErr.Key != tt.key || parseErr.Value != "80a" || parseErr.Type != "int" {
+				t.Errorf("ParseError = %+v", parseErr)
+			}
+			if !errors.Is(err, strconv.ErrSyntax) {
+				t.Errorf("GetIntE() error = %v, want it to wrap strconv.ErrSyntax", err)
+			}
+		})
+	}
+}
+
+func TestGetIntSliceE(t *testing.T) {
+	t.Setenv("TEST_INTSLICEE_INVALID", "1,x")
+
+	got, err := GetIntSliceE("TEST_INTSLICEE_INVALID", []int{7})
+	if err == nil {
+		t.Fatal("GetIntSliceE() error = nil, want error")
+	}
+	if len(got) != 1 || got[0] != 7 {
+		t.Errorf("GetIntSliceE() = %v, want default [7]", got)
+	}
+	if want := "env TEST_INTSLICEE_INVALID: invalid []int value: invalid int value at index 1"; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("GetIntSliceE() error = %q, want prefix %q", err, want)
+	}
+}
+
+func TestMustGetInt(t *testing.T) {
+	t.Setenv("TEST_MUST_INT", "80a")
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("MustGetInt() did not panic")
+		}
+		msg, _ := r.(string)
+		if !strings.HasPrefix(msg, "envconfig: env TEST_MUST_INT: invalid int value") {
+			t.Errorf("MustGetInt() panic = %q", msg)
+		}
+	}()
+
+	MustGetInt("TEST_MUST_INT", 8080)
+}
+
+func TestMustGetDurationValid(t *testing.T) {
+	if got := MustGetDuration("TEST_MUST_DURATION_MISSING", 5); got != 5 {
+		t.Errorf("MustGetDuration() = %v, want default", got)
+	}
+}
+
+func TestSetFallbackHook(t *testing.T) {
+	t.Setenv("TEST_FALLBACK_PORT", "80a")
+	t.Setenv("TEST_FALLBACK_OK", "1")
+
+	var got []*ParseError
+	SetFallbackHook(func(err *ParseError) {
+		got = append(got, err)
+	})
+	defer SetFallbackHook(nil)
+
+	if port := GetInt("TEST_FALLBACK_PORT", 8080); port != 8080 {
+		t.Errorf("GetInt() = %v, want 8080", port)
+	}
+	GetInt("TEST_FALLBACK_OK", 0)
+	GetInt("TEST_FALLBACK_MISSING", 0)
+
+	if len(got) != 1 {
+		t.Fatalf("hook called %d times, want 1", len(got))
+	}
+	if got[0].Key != "TEST_FALLBACK_PORT" {
+		t.Errorf("hook error = %v", got[0])
+	}
+
+	SetFallbackHook(nil)
+	GetInt("TEST_FALLBACK_PORT", 8080)
+	if len(got) != 1 {
+		t.Errorf("hook called after removal")
+	}
+}
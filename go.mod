@@ -1,5 +1,3 @@
 module github.com/pgmod/envconfig
 
 go 1.23.2
-
-require github.com/joho/godotenv v1.5.1
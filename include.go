@@ -0,0 +1,101 @@
+package envconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// envEntry is a variable read from a .env file, with the file and line
+// that assigned it.
+type envEntry struct {
+	value string
+	file  string
+	line  int
+}
+
+// envVars holds the variables of a .env file and the files it includes,
+// in the order they were first assigned.
+type envVars struct {
+	keys    []string
+	entries map[string]envEntry
+}
+
+func (v *envVars) set(key string, entry envEntry) {
+	if _, ok := v.entries[key]; !ok {
+		v.keys = append(v.keys, key)
+	}
+	v.entries[key] = entry
+}
+
+// envFileReader reads .env files and follows their include directives.
+type envFileReader struct {
+	// names and paths hold the files being read, outermost first:
+	// the names as written and their absolute paths, for cycle detection.
+	names []string
+	paths []string
+}
+
+// readEnvFile reads the .env file at path together with the files it includes.
+// Paths in include directives are relative to the directory of the including
+// file. Variables assigned in a file take precedence over those of the files
+// it includes, and of two included files the one included later wins.
+func readEnvFile(path string) (*envVars, error) {
+	return (&envFileReader{}).read(path)
+}
+
+func (r *envFileReader) read(path string) (*envVars, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range r.paths {
+		if p == abs {
+			chain := append(append([]string(nil), r.names[i:]...), path)
+			return nil, fmt.Errorf("include cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r.names = append(r.names, path)
+	r.paths = append(r.paths, abs)
+	defer func() {
+		r.names = r.names[:len(r.names)-1]
+		r.paths = r.paths[:len(r.paths)-1]
+	}()
+
+	vars := &envVars{entries: make(map[string]envEntry)}
+	p := newEnvParser(string(data), path)
+	p.include = func(target string) (map[string]string, error) {
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		included, err := r.read(target)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]string, len(included.keys))
+		for _, key := range included.keys {
+			entry := included.entries[key]
+			vars.set(key, entry)
+			values[key] = entry.value
+		}
+		return values, nil
+	}
+
+	file, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range file.Nodes {
+		if node.Kind == AssignmentNode {
+			vars.set(node.Key, envEntry{value: node.Value, file: path, line: node.Line})
+		}
+	}
+	return vars, nil
+}
@@ -0,0 +1,147 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeEnvFiles writes files relative to a temporary directory and returns it.
+func writeEnvFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadInclude(t *testing.T) {
+	resetDotEnv(t)
+	dir := writeEnvFiles(t, map[string]string{
+		"shared.env":     "INC_HOST=shared.local\nINC_PORT=5432\nINC_USER=shared\n",
+		"svc/base.env":   "INC_USER=base\nINC_LEVEL=debug\n",
+		"svc/.env":       "INC_HOST=svc.local\n#include ../shared.env\n@import base.env\nINC_URL=${INC_HOST}:${INC_PORT}\n",
+		"svc/unused.env": "INC_UNUSED=1\n",
+	})
+	envFile := filepath.Join(dir, "svc", ".env")
+	t.Setenv(EnvFileKey, envFile)
+	for _, key := range []string{"INC_HOST", "INC_PORT", "INC_USER", "INC_LEVEL", "INC_URL", "INC_UNUSED"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	if err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var cfg struct {
+		Host  string `env:"INC_HOST"`
+		Port  int    `env:"INC_PORT"`
+		User  string `env:"INC_USER"`
+		Level string `env:"INC_LEVEL"`
+		URL   string `env:"INC_URL"`
+	}
+	var md Metadata
+	if err := LoadStruct(&cfg, WithMetadata(&md)); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.Host != "svc.local" || cfg.Port != 5432 || cfg.User != "base" || cfg.Level != "debug" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if cfg.URL != "svc.local:5432" {
+		t.Errorf("URL = %q, want svc.local:5432", cfg.URL)
+	}
+	if _, exists := os.LookupEnv("INC_UNUSED"); exists {
+		t.Error("Load() read a file that is not included")
+	}
+
+	tests := []struct {
+		path string
+		file string
+		line int
+	}{
+		{"Host", envFile, 1},
+		{"Port", filepath.Join(dir, "shared.env"), 2},
+		{"User", filepath.Join(dir, "svc", "base.env"), 1},
+		{"URL", envFile, 4},
+	}
+	for _, tt := range tests {
+		got, _ := md.Origin(tt.path)
+		if got.Kind != SourceDotEnv || got.File != tt.file || got.Line != tt.line {
+			t.Errorf("Origin(%q) = %+v, want %s:%d", tt.path, got, tt.file, tt.line)
+		}
+	}
+}
+
+func TestLoadIncludeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  func(dir string) string
+	}{
+		{
+			name:  "cycle",
+			files: map[string]string{".env": "A=1\n#include sub/b.env\n", "sub/b.env": "@import ../.env\n"},
+			want: func(dir string) string {
+				return filepath.Join(dir, "sub", "b.env") + ":1: include cycle: " +
+					filepath.Join(dir, ".env") + " -> " + filepath.Join(dir, "sub", "b.env") + " -> " + filepath.Join(dir, ".env")
+			},
+		},
+		{
+			name:  "self",
+			files: map[string]string{".env": "#include .env\n"},
+			want: func(dir string) string {
+				env := filepath.Join(dir, ".env")
+				return env + ":1: include cycle: " + env + " -> " + env
+			},
+		},
+		{
+			name:  "syntax error in included file",
+			files: map[string]string{".env": "#include b.env\n", "b.env": "\nB='x\n"},
+			want: func(dir string) string {
+				return filepath.Join(dir, "b.env") + ":2: unterminated quote"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetDotEnv(t)
+			dir := writeEnvFiles(t, tt.files)
+			t.Setenv(EnvFileKey, filepath.Join(dir, ".env"))
+
+			err := Load()
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Load() error = %v, want *SyntaxError", err)
+			}
+			if want := tt.want(dir); err.Error() != want {
+				t.Errorf("Load() error = %q, want %q", err, want)
+			}
+		})
+	}
+}
+
+func TestLoadIncludeMissingFile(t *testing.T) {
+	resetDotEnv(t)
+	dir := writeEnvFiles(t, map[string]string{".env": "A=1\n\n#include missing.env\n"})
+	t.Setenv(EnvFileKey, filepath.Join(dir, ".env"))
+
+	err := Load()
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Load() error = %v, want os.ErrNotExist", err)
+	}
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Line != 3 {
+		t.Errorf("Load() error = %v, want error at line 3", err)
+	}
+}
@@ -4,10 +4,8 @@ import (
 	"fmt"
 	"os"
 	"reflect"
-	"strconv"
 	"strings"
-
-	"github.com/joho/godotenv"
+	"time"
 )
 
 const (
@@ -20,61 +18,152 @@ const (
 // Load loads environment variables from a .env file.
 // By default, it looks for a file named ".env" in the current directory.
 // You can specify a custom file path by setting the ENV_FILE environment variable.
+// Variables already set in the environment are not overridden.
+// See ParseEnv for the syntax; syntax errors are reported as *SyntaxError
+// with the file name and line, e.g. ".env:14: unterminated quote".
+//
+// The lines "#include path" and "@import path" read another .env file, with
+// path relative to the directory of the including file. Variables assigned in
+// the including file take precedence over the included ones. The provenance
+// of each variable, see WithMetadata, names the file that assigned it.
+// Include cycles are reported as errors.
 func Load() error {
 	envFile := Get(EnvFileKey, DefaultEnvFile)
-	return godotenv.Load(envFile)
+
+	vars, err := readEnvFile(envFile)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range vars.keys {
+		entry := vars.entries[key]
+		if _, exists := os.LookupEnv(key); exists {
+			recordDotEnv(key, entry.value, entry.file, entry.line, false)
+			continue
+		}
+		if err := os.Setenv(key, entry.value); err != nil {
+			return err
+		}
+		recordDotEnv(key, entry.value, entry.file, entry.line, true)
+	}
+
+	return nil
 }
 
 // LoadStruct loads configuration from environment variables into a struct.
 // It uses the "env" tag to specify the environment variable name
 // and the "default" tag to specify a default value.
+// Fields tagged with required:"true" must be set in the environment
+// unless they have a default value.
+// Values of fields tagged with secret:"true" never appear in error messages.
+// Besides basic kinds, fields may be slog.Level, *time.Location (by IANA name),
+// *regexp.Regexp, os.FileMode (in octal), url.URL (with the allowed schemes listed
+// in the "schemes" tag), net.IP, HostPort, ByteSize, Rate, any encoding.TextUnmarshaler such
+// as netip.Addr and netip.Prefix, and pointers to these types.
+// The "format" tag selects another encoding of the value: "json" decodes it
+// with encoding/json into a field of any type, "base64" and "hex" into []byte.
+// Integer fields tagged with unit:"bytes" accept sizes such as "512KiB".
+// The "enum" tag maps names to values, e.g. enum:"fast=1,safe=2,off=0";
+// integer types with a String method are decoded from their names, and
+// RegisterParser adds a decoder for any type. Fields tagged with unquote:"true",
+// or all fields with WithUnquote, have quoted values from the environment
+// interpreted as in a .env file, with escape sequences expanded only inside
+// double quotes. The "transform" tag lists transforms such as "trim,lower"
+// applied to the raw value before decoding; see RegisterTransform.
+//
+// A variable set to an empty string is treated as not set, so the default
+// applies; see EmptyPolicy and the notEmpty and allowEmpty tags.
+//
+// If a variable is not set but <NAME>_FILE is, the value is read from
+// the file it points to. Pass WithMetadata to find out where each value
+// came from, and Strict to reject variables that no field maps to.
+//
+// Slices of structs without an "env" tag are loaded from indexed variables:
+// with prefix:"UPSTREAMS_", element i is loaded from UPSTREAMS_<i>_HOST and so on.
+// Indices must start at 0 and have no gaps. Maps of structs with string keys
+// are filled from the names found between the prefix and the field names:
+// with prefix:"DB_", DB_PRIMARY_HOST becomes the element with key "primary".
+// The "keycase" tag of the map field ("lower", "upper" or "keep") sets how
+// the keys are normalised; the default is "lower".
 //
-// Supported field types: string, bool, int, int64, []int, []int64, and arrays of int.
+// Variables are read from the process environment, or from the Source
+// given by WithSource.
+//
+// The "aliases" tag lists old names of a variable that are still read,
+// in order, when the variable itself is not set; setting them to values
+// different from the variable is an error. Using an alias, or a variable
+// tagged with deprecated:"message", is reported through OnWarning.
+//
+// Pass WithAutoNames to load fields without an "env" tag too, with names
+// derived from the field names.
+//
+// Before reading the environment LoadStruct validates the tags of cfg
+// the same way CheckStruct does.
+//
+// Struct fields without an "env" tag are loaded as nested configuration.
+// The "prefix" tag of such a field is prepended to the variable names
+// of its fields.
+//
+// Supported field types: string, bool, signed and unsigned integers, floats,
+// time.Duration, and slices and arrays of those types.
 //
 // Example:
 //
 //	type Config struct {
 //	    Host string `env:"HOST" default:"localhost"`
 //	    Port int    `env:"PORT" default:"8080"`
+//	    DSN  string `env:"DSN" required:"true" desc:"database connection string"`
+//	    DB   struct {
+//	        Password string `env:"PASSWORD" secret:"true"` // DB_PASSWORD
+//	    } `prefix:"DB_"`
 //	}
 //
 //	var cfg Config
 //	if err := envconfig.LoadStruct(&cfg); err != nil {
 //	    log.Fatal(err)
 //	}
-func LoadStruct(cfg any) error {
-	v := reflect.ValueOf(cfg)
-	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
-		return fmt.Errorf("cfg must be pointer to struct")
+func LoadStruct(cfg any, opts ...Option) error {
+	l := newLoader(opts)
+	l.mode = walkLoad
+	specs, err := l.collectFields(cfg)
+	if err != nil {
+		return err
+	}
+	if err := checkSpecs(specs); err != nil {
+		return err
 	}
 
-	v = v.Elem()
-	t := v.Type()
-
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		fieldType := t.Field(i)
+	for _, spec := range specs {
+		envValue, origin, err := l.resolve(spec)
+		if err != nil {
+			return err
+		}
+		if spec.required && origin.Kind == SourceNone {
+			return fmt.Errorf("env %s: required variable is not set", spec.envName)
+		}
+		l.warnDeprecated(spec)
 
-		envName := fieldType.Tag.Get("env")
-		if envName == "" {
-			continue
+		if err := setValue(spec.value, envValue, l.decodeOptions(spec)); err != nil {
+			if spec.secret {
+				err = redactError(err, envValue)
+			}
+			return fmt.Errorf("env %s: %w", spec.envName, err)
 		}
 
-		envValue := getEnvValue(envName, fieldType.Tag.Get("default"))
-		if err := setValue(field, envValue); err != nil {
-			return fmt.Errorf("env %s: %w", envName, err)
+		if l.metadata != nil {
+			l.metadata.record(spec.path, origin)
 		}
 	}
 
-	return nil
-}
+	for _, store := range l.afterLoad {
+		store()
+	}
 
-// getEnvValue retrieves the environment variable value or returns the default.
-func getEnvValue(envName, defaultValue string) string {
-	if value, exists := os.LookupEnv(envName); exists {
-		return value
+	if l.strict {
+		return l.checkUnknown(specs)
 	}
-	return defaultValue
+
+	return nil
 }
 
 // ToList splits a string into a list of strings by the specified separator.
@@ -83,7 +172,8 @@ func ToList(value string, separator string) ([]string, error) {
 }
 
 // Get retrieves a string value from environment variables with a default value.
-// Returns the default value if the environment variable is not set or is empty.
+// Returns the default value if the environment variable is not set or is empty,
+// following the default EmptyAsUnset policy.
 func Get(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -91,54 +181,102 @@ func Get(key, defaultValue string) string {
 	return defaultValue
 }
 
-// GetBool retrieves a boolean value from environment variables with a default value.
-// Returns the default value if the environment variable is not set, is empty, or cannot be parsed.
+// Lookup retrieves the environment variable key and decodes it as T.
+// It supports every type LoadStruct supports. The second result is false
+// if the variable is not set, or is empty under the EmptyAsUnset policy;
+// the error is a *ParseError if the value cannot be decoded.
+// Pass WithEmptyPolicy to change how empty values are treated, and
+// WithUnquote to unquote the value as LoadStruct does. Options that only
+// concern struct fields, such as WithAutoNames or WithMetadata, are ignored.
 //
-// Supported values: true, false, 1, 0, t, f, T, F, TRUE, FALSE, True, False
-func GetBool(key string, defaultValue bool) bool {
-	value := Get(key, "")
-	if value == "" {
-		return defaultValue
+// Example:
+//
+//	timeout, ok, err := envconfig.Lookup[time.Duration]("TIMEOUT")
+func Lookup[T any](key string, opts ...Option) (T, bool, error) {
+	var result T
+
+	l := newLoader(opts)
+	v := reflect.ValueOf(&result).Elem()
+
+	value, exists := l.source.Lookup(key)
+	if !exists {
+		return result, false, nil
+	}
+	// Values read from a .env file were already unquoted by Load.
+	if _, fromDotEnv := dotenvOrigin(key, value); l.unquote && !(fromDotEnv && l.fromEnvironment()) {
+		unquoted, err := unquoteValue(value)
+		if err != nil {
+			return result, true, &ParseError{Key: key, Value: value, Type: v.Type().String(), Err: err}
+		}
+		value = unquoted
+	}
+	if value == "" && l.empty == EmptyAsUnset {
+		return result, false, nil
+	}
+	if value == "" && l.empty == EmptyIsError {
+		return result, true, &ParseError{Key: key, Value: value, Type: v.Type().String(), Err: ErrEmptyValue}
 	}
 
-	parsed, err := strconv.ParseBool(value)
-	if err != nil {
-		return defaultValue
+	if err := setValue(v, value, decodeOptions{empty: l.empty}); err != nil {
+		var zero T
+		return zero, true, &ParseError{Key: key, Value: value, Type: v.Type().String(), Err: err}
 	}
 
-	return parsed
+	return result, true, nil
 }
 
-// GetInt retrieves an integer value from environment variables with a default value.
+// GetAs retrieves the environment variable key decoded as T with a default value.
+// It supports every type LoadStruct supports.
 // Returns the default value if the environment variable is not set, is empty, or cannot be parsed.
-func GetInt(key string, defaultValue int) int {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-
-	parsed, err := strconv.Atoi(value)
+// In the last case the hook registered with SetFallbackHook is notified.
+// It accepts the same options as Lookup.
+//
+// Example:
+//
+//	ratio := envconfig.GetAs("RATIO", 0.5)
+//	hosts := envconfig.GetAs("HOSTS", []string{"localhost"})
+func GetAs[T any](key string, defaultValue T, opts ...Option) T {
+	value, err := GetAsE(key, defaultValue, opts...)
 	if err != nil {
+		if parseErr, ok := err.(*ParseError); ok {
+			notifyFallback(parseErr)
+		}
 		return defaultValue
 	}
+	return value
+}
 
-	return parsed
+// GetBool retrieves a boolean value from environment variables with a default value.
+// Returns the default value if the environment variable is not set, is empty, or cannot be parsed.
+//
+// Supported values, case-insensitive: true, false, 1, 0, t, f, y, n, yes, no,
+// on, off, enable, disable, enabled, disabled. See SetBoolWords.
+func GetBool(key string, defaultValue bool) bool {
+	return GetAs(key, defaultValue)
+}
+
+// GetInt retrieves an integer value from environment variables with a default value.
+// Returns the default value if the environment variable is not set, is empty, or cannot be parsed.
+func GetInt(key string, defaultValue int) int {
+	return GetAs(key, defaultValue)
 }
 
 // GetInt64 retrieves a 64-bit integer value from environment variables with a default value.
 // Returns the default value if the environment variable is not set, is empty, or cannot be parsed.
 func GetInt64(key string, defaultValue int64) int64 {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
+	return GetAs(key, defaultValue)
+}
 
-	parsed, err := strconv.ParseInt(value, 10, 64)
-	if err != nil {
-		return defaultValue
-	}
+// GetFloat64 retrieves a floating-point value from environment variables with a default value.
+// Returns the default value if the environment variable is not set, is empty, or cannot be parsed.
+func GetFloat64(key string, defaultValue float64) float64 {
+	return GetAs(key, defaultValue)
+}
 
-	return parsed
+// GetDuration retrieves a time.Duration such as "1h30m" from environment variables with a default value.
+// Returns the default value if the environment variable is not set, is empty, or cannot be parsed.
+func GetDuration(key string, defaultValue time.Duration) time.Duration {
+	return GetAs(key, defaultValue)
 }
 
 // GetIntSlice retrieves a slice of integers from environment variables with a default value.
@@ -150,17 +288,7 @@ func GetInt64(key string, defaultValue int64) int64 {
 //	PORTS=8080,8081,8082
 //	ports := GetIntSlice("PORTS", []int{3000, 3001})
 func GetIntSlice(key string, defaultValue []int) []int {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-
-	slice, err := parseIntSlice(value)
-	if err != nil {
-		return defaultValue
-	}
-
-	return slice
+	return GetAs(key, defaultValue)
 }
 
 // GetInt64Slice retrieves a slice of 64-bit integers from environment variables with a default value.
@@ -172,61 +300,17 @@ func GetIntSlice(key string, defaultValue []int) []int {
 //	MAX_SIZES=1024,2048,4096
 //	maxSizes := GetInt64Slice("MAX_SIZES", []int64{512, 1024})
 func GetInt64Slice(key string, defaultValue []int64) []int64 {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-
-	slice, err := parseInt64Slice(value)
-	if err != nil {
-		return defaultValue
-	}
-
-	return slice
-}
-
-// parseIntSlice parses a comma-separated string into a slice of integers.
-func parseIntSlice(value string) ([]int, error) {
-	parts := strings.Split(value, ",")
-	result := make([]int, 0, len(parts))
-
-	for i, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			result = append(result, 0)
-			continue
-		}
-
-		parsed, err := strconv.Atoi(part)
-		if err != nil {
-			return nil, fmt.Errorf("invalid int value at index %d: %w", i, err)
-		}
-
-		result = append(result, parsed)
-	}
-
-	return result, nil
+	return GetAs(key, defaultValue)
 }
 
-// parseInt64Slice parses a comma-separated string into a slice of 64-bit integers.
-func parseInt64Slice(value string) ([]int64, error) {
-	parts := strings.Split(value, ",")
-	result := make([]int64, 0, len(parts))
-
-	for i, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			result = append(result, 0)
-			continue
-		}
-
-		parsed, err := strconv.ParseInt(part, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid int64 value at index %d: %w", i, err)
-		}
-
-		result = append(result, parsed)
-	}
-
-	return result, nil
+// GetStringSlice retrieves a slice of strings from environment variables with a default value.
+// Values should be comma-separated. Spaces around values are automatically trimmed.
+// Returns the default value if the environment variable is not set or is empty.
+//
+// Example:
+//
+//	HOSTS=a.example.com, b.example.com
+//	hosts := GetStringSlice("HOSTS", []string{"localhost"})
+func GetStringSlice(key string, defaultValue []string) []string {
+	return GetAs(key, defaultValue)
 }
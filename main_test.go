@@ -2,7 +2,10 @@ package envconfig
 
 import (
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGet(t *testing.T) {
@@ -185,6 +188,38 @@ func TestGetBool(t *testing.T) {
 			envValue:     "0",
 			want:         false,
 		},
+		{
+			name:         "handles 'yes' as true",
+			key:          "TEST_BOOL_YES",
+			defaultValue: false,
+			setEnv:       true,
+			envValue:     "yes",
+			want:         true,
+		},
+		{
+			name:         "handles 'Off' as false",
+			key:          "TEST_BOOL_OFF",
+			defaultValue: true,
+			setEnv:       true,
+			envValue:     "Off",
+			want:         false,
+		},
+		{
+			name:         "handles 'ENABLED' as true",
+			key:          "TEST_BOOL_ENABLED",
+			defaultValue: false,
+			setEnv:       true,
+			envValue:     "ENABLED",
+			want:         true,
+		},
+		{
+			name:         "returns default for unknown word",
+			key:          "TEST_BOOL_UNKNOWN",
+			defaultValue: true,
+			setEnv:       true,
+			envValue:     "nope",
+			want:         true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -411,12 +446,36 @@ func TestGetIntSlice(t *testing.T) {
 			want:         []int{-10, -20, -30},
 		},
 		{
-			name:         "handles empty values in slice",
+			name:         "skips empty values in slice",
 			key:          "TEST_INT_SLICE_EMPTY_VALUES",
 			defaultValue: []int{0},
 			setEnv:       true,
 			envValue:     "1,,3",
-			want:         []int{1, 0, 3},
+			want:         []int{1, 3},
+		},
+		{
+			name:         "expands ranges",
+			key:          "TEST_INT_SLICE_RANGE",
+			defaultValue: []int{0},
+			setEnv:       true,
+			envValue:     "8080-8083, 9000",
+			want:         []int{8080, 8081, 8082, 8083, 9000},
+		},
+		{
+			name:         "expands ranges with negative bounds",
+			key:          "TEST_INT_SLICE_NEGATIVE_RANGE",
+			defaultValue: []int{0},
+			setEnv:       true,
+			envValue:     "-2--1,-1-1",
+			want:         []int{-2, -1, -1, 0, 1},
+		},
+		{
+			name:         "returns default when range is reversed",
+			key:          "TEST_INT_SLICE_REVERSED_RANGE",
+			defaultValue: []int{999},
+			setEnv:       true,
+			envValue:     "8085-8080",
+			want:         []int{999},
 		},
 		{
 			name:         "returns default when environment variable is invalid",
@@ -508,12 +567,20 @@ func TestGetInt64Slice(t *testing.T) {
 			want:         []int64{-9223372036854775808, -10},
 		},
 		{
-			name:         "handles empty values in slice",
+			name:         "skips empty values in slice",
 			key:          "TEST_INT64_SLICE_EMPTY_VALUES",
 			defaultValue: []int64{0},
 			setEnv:       true,
 			envValue:     "1,,3",
-			want:         []int64{1, 0, 3},
+			want:         []int64{1, 3},
+		},
+		{
+			name:         "expands ranges",
+			key:          "TEST_INT64_SLICE_RANGE",
+			defaultValue: []int64{0},
+			setEnv:       true,
+			envValue:     "1,5-7",
+			want:         []int64{1, 5, 6, 7},
 		},
 		{
 			name:         "returns default when environment variable is invalid",
@@ -929,6 +996,37 @@ func TestLoadStruct(t *testing.T) {
 			wantErr:  true,
 			validate: func(t *testing.T, cfg interface{}) {},
 		},
+		{
+			name: "returns error when required variable is not set",
+			cfg: &struct {
+				DSN string `env:"TEST_DSN_REQUIRED" required:"true"`
+			}{},
+			setupEnv: func() {
+				os.Unsetenv("TEST_DSN_REQUIRED")
+			},
+			cleanupEnv: func() {},
+			wantErr:    true,
+			validate:   func(t *testing.T, cfg interface{}) {},
+		},
+		{
+			name: "uses default for required variable",
+			cfg: &struct {
+				DSN string `env:"TEST_DSN_REQUIRED_DEFAULT" required:"true" default:"postgres://localhost"`
+			}{},
+			setupEnv: func() {
+				os.Unsetenv("TEST_DSN_REQUIRED_DEFAULT")
+			},
+			cleanupEnv: func() {},
+			wantErr:    false,
+			validate: func(t *testing.T, cfg interface{}) {
+				c := cfg.(*struct {
+					DSN string `env:"TEST_DSN_REQUIRED_DEFAULT" required:"true" default:"postgres://localhost"`
+				})
+				if c.DSN != "postgres://localhost" {
+					t.Errorf("DSN = %v, want postgres://localhost", c.DSN)
+				}
+			},
+		},
 		{
 			name: "returns error when cfg is not a pointer",
 			cfg: struct {
@@ -973,3 +1071,251 @@ func TestLoadStruct(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadStructNested(t *testing.T) {
+	t.Setenv("TEST_NESTED_DB_HOST", "db.example.com")
+	t.Setenv("TEST_NESTED_DB_POOL_SIZE", "20")
+	t.Setenv("TEST_NESTED_NAME", "app")
+
+	var cfg struct {
+		DB struct {
+			Host string `env:"HOST"`
+			Port int    `env:"PORT" default:"5432"`
+			Pool struct {
+				Size int `env:"SIZE"`
+			} `prefix:"POOL_"`
+		} `prefix:"TEST_NESTED_DB_"`
+		// Without a prefix tag the fields keep their own names.
+		App struct {
+			Name string `env:"TEST_NESTED_NAME"`
+		}
+	}
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if cfg.DB.Host != "db.example.com" {
+		t.Errorf("DB.Host = %v, want db.example.com", cfg.DB.Host)
+	}
+	if cfg.DB.Port != 5432 {
+		t.Errorf("DB.Port = %v, want 5432", cfg.DB.Port)
+	}
+	if cfg.DB.Pool.Size != 20 {
+		t.Errorf("DB.Pool.Size = %v, want 20", cfg.DB.Pool.Size)
+	}
+	if cfg.App.Name != "app" {
+		t.Errorf("App.Name = %v, want app", cfg.App.Name)
+	}
+}
+
+func TestLoadStructNestedErrors(t *testing.T) {
+	t.Setenv("TEST_NESTED_ERR_DB_PORT", "abc")
+
+	var cfg struct {
+		DB struct {
+			Port int    `env:"PORT"`
+			User string `env:"USER" required:"true"`
+		} `prefix:"TEST_NESTED_ERR_DB_"`
+	}
+
+	err := LoadStruct(&cfg)
+	if err == nil || !strings.HasPrefix(err.Error(), "env TEST_NESTED_ERR_DB_PORT: ") {
+		t.Errorf("LoadStruct() error = %v, want it to name TEST_NESTED_ERR_DB_PORT", err)
+	}
+
+	t.Setenv("TEST_NESTED_ERR_DB_PORT", "5432")
+	err = LoadStruct(&cfg)
+	if err == nil || err.Error() != "env TEST_NESTED_ERR_DB_USER: required variable is not set" {
+		t.Errorf("LoadStruct() error = %v, want required TEST_NESTED_ERR_DB_USER", err)
+	}
+}
+
+func TestGetAs(t *testing.T) {
+	t.Setenv("TEST_GETAS_FLOAT", "0.25")
+	t.Setenv("TEST_GETAS_DURATION", "1m30s")
+	t.Setenv("TEST_GETAS_STRINGS", "a, b ,c")
+	t.Setenv("TEST_GETAS_UINT8", "255")
+	t.Setenv("TEST_GETAS_INVALID", "80a")
+	t.Setenv("TEST_GETAS_OVERFLOW", "256")
+
+	if got := GetAs("TEST_GETAS_FLOAT", 1.0); got != 0.25 {
+		t.Errorf("GetAs[float64]() = %v, want 0.25", got)
+	}
+	if got := GetAs("TEST_GETAS_DURATION", time.Second); got != 90*time.Second {
+		t.Errorf("GetAs[time.Duration]() = %v, want 1m30s", got)
+	}
+	if got := GetAs("TEST_GETAS_STRINGS", []string(nil)); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("GetAs[[]string]() = %q, want [a b c]", got)
+	}
+	if got := GetAs("TEST_GETAS_UINT8", uint8(1)); got != 255 {
+		t.Errorf("GetAs[uint8]() = %v, want 255", got)
+	}
+	if got := GetAs("TEST_GETAS_OVERFLOW", uint8(1)); got != 1 {
+		t.Errorf("GetAs[uint8]() on overflow = %v, want default 1", got)
+	}
+	if got := GetAs("TEST_GETAS_INVALID", 8080); got != 8080 {
+		t.Errorf("GetAs[int]() on invalid value = %v, want default 8080", got)
+	}
+	if got := GetAs("TEST_GETAS_MISSING", "fallback"); got != "fallback" {
+		t.Errorf("GetAs[string]() on missing value = %v, want fallback", got)
+	}
+
+	if got := GetFloat64("TEST_GETAS_FLOAT", 1); got != 0.25 {
+		t.Errorf("GetFloat64() = %v, want 0.25", got)
+	}
+	if got := GetDuration("TEST_GETAS_DURATION", 0); got != 90*time.Second {
+		t.Errorf("GetDuration() = %v, want 1m30s", got)
+	}
+	if got := GetStringSlice("TEST_GETAS_MISSING", []string{"x"}); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("GetStringSlice() = %q, want default [x]", got)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Setenv("TEST_LOOKUP_VALID", "1.5s")
+	t.Setenv("TEST_LOOKUP_INVALID", "soon")
+	t.Setenv("TEST_LOOKUP_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		key     string
+		want    time.Duration
+		wantOK  bool
+		wantErr bool
+	}{
+		{name: "valid value", key: "TEST_LOOKUP_VALID", want: 1500 * time.Millisecond, wantOK: true},
+		{name: "invalid value", key: "TEST_LOOKUP_INVALID", wantOK: true, wantErr: true},
+		{name: "empty value", key: "TEST_LOOKUP_EMPTY"},
+		{name: "missing value", key: "TEST_LOOKUP_MISSING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := Lookup[time.Duration](tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.key) {
+				t.Errorf("Lookup() error = %q, want it to name %s", err, tt.key)
+			}
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Lookup() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLoadStructExtendedTypes(t *testing.T) {
+	t.Setenv("TEST_EXT_TIMEOUT", "250ms")
+	t.Setenv("TEST_EXT_RATIO", "0.75")
+	t.Setenv("TEST_EXT_HOSTS", "a,b")
+	t.Setenv("TEST_EXT_WEIGHTS", "0.5,1.5")
+	t.Setenv("TEST_EXT_SMALL", "300")
+
+	var cfg struct {
+		Timeout time.Duration   `env:"TEST_EXT_TIMEOUT"`
+		Ratio   float32         `env:"TEST_EXT_RATIO"`
+		Hosts   []string        `env:"TEST_EXT_HOSTS"`
+		Weights [2]float64      `env:"TEST_EXT_WEIGHTS"`
+		Retries uint            `env:"TEST_EXT_RETRIES" default:"3"`
+		Delays  []time.Duration `env:"TEST_EXT_DELAYS" default:"1s,2s"`
+	}
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.Timeout != 250*time.Millisecond || cfg.Ratio != 0.75 || cfg.Retries != 3 {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.Hosts, []string{"a", "b"}) || cfg.Weights != [2]float64{0.5, 1.5} {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.Delays, []time.Duration{time.Second, 2 * time.Second}) {
+		t.Errorf("Delays = %v, want [1s 2s]", cfg.Delays)
+	}
+
+	var small struct {
+		Small int8 `env:"TEST_EXT_SMALL"`
+	}
+	if err := LoadStruct(&small); err == nil {
+		t.Error("LoadStruct() error = nil, want out of range error for int8")
+	}
+}
+
+func TestLoadStructIntRanges(t *testing.T) {
+	type config struct {
+		Ports   []uint16      `env:"RANGE_PORTS"`
+		Workers [3]int        `env:"RANGE_WORKERS"`
+		Timeout time.Duration `env:"RANGE_TIMEOUT"`
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    config
+		wantErr string
+	}{
+		{
+			name: "ranges in slice and array",
+			env:  map[string]string{"RANGE_PORTS": "8080-8082,9000", "RANGE_WORKERS": "1-3"},
+			want: config{Ports: []uint16{8080, 8081, 8082, 9000}, Workers: [3]int{1, 2, 3}},
+		},
+		{
+			name:    "reversed range names the index",
+			env:     map[string]string{"RANGE_PORTS": "80,8085-8080"},
+			wantErr: "env RANGE_PORTS: invalid uint16 value at index 1: range 8085-8080 is reversed",
+		},
+		{
+			name:    "range too large",
+			env:     map[string]string{"RANGE_PORTS": "0-100000"},
+			wantErr: "env RANGE_PORTS: invalid uint16 value at index 0: range 0-100000 has more than 65536 values",
+		},
+		{
+			name:    "ranges together too large",
+			env:     map[string]string{"RANGE_PORTS": "0-65535,1"},
+			wantErr: "env RANGE_PORTS: list has more than 65536 values",
+		},
+		{
+			name:    "range exceeds element type",
+			env:     map[string]string{"RANGE_PORTS": "65535-65536"},
+			wantErr: "env RANGE_PORTS: invalid uint16 value at index 0",
+		},
+		{
+			name:    "index is the position in the variable",
+			env:     map[string]string{"RANGE_PORTS": "1,,3-5,x"},
+			wantErr: `env RANGE_PORTS: invalid uint16 value at index 3: strconv.ParseUint: parsing "x"`,
+		},
+		{
+			name:    "range length must match array",
+			env:     map[string]string{"RANGE_WORKERS": "1-4"},
+			wantErr: "env RANGE_WORKERS: array length mismatch: got 4 values, expected 3",
+		},
+		{
+			name:    "ranges only apply to plain integers",
+			env:     map[string]string{"RANGE_TIMEOUT": "1s-2s"},
+			wantErr: "env RANGE_TIMEOUT:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			var cfg config
+			err := LoadStruct(&cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadStruct() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg, tt.want) {
+				t.Errorf("LoadStruct() = %+v, want %+v", cfg, tt.want)
+			}
+		})
+	}
+}
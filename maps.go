@@ -0,0 +1,144 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// walkMap appends the specs of the elements of a map of structs to specs.
+// The fields of the element with key k use the prefix followed by "<K>_",
+// e.g. DB_PRIMARY_HOST for the key "primary".
+//
+// When loading, the keys are discovered by scanning the source for variables
+// that start with the prefix and end with a variable name of the element,
+// and are normalised according to the "keycase" tag of the map field.
+// When describing the struct, a single placeholder element is walked.
+// The prefix must not be empty, or every variable ending in a field name
+// of the element would become an element of the map.
+func (l *loader) walkMap(field reflect.Value, fieldType reflect.StructField, path, prefix string, specs *[]fieldSpec) error {
+	if prefix == "" {
+		return fmt.Errorf("field %s: map of structs requires a prefix tag", path)
+	}
+
+	keyCase := fieldType.Tag.Get("keycase")
+	switch keyCase {
+	case "":
+		keyCase = "lower"
+	case "lower", "upper", "keep":
+	default:
+		return fmt.Errorf("field %s: invalid keycase tag %q", path, keyCase)
+	}
+
+	t := field.Type()
+	walkElem := func(elem reflect.Value, key, name string) error {
+		return l.walkFields(elem, path+"["+key+"]", prefix+name+"_", specs)
+	}
+
+	switch l.mode {
+	case walkSchema:
+		return walkElem(reflect.New(t.Elem()).Elem(), l.keyPlaceholder, l.keyPlaceholder)
+
+	case walkLoad:
+		names, err := l.discoverKeys(t.Elem(), prefix)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			if field.CanSet() {
+				field.Set(reflect.Zero(t))
+			}
+			return nil
+		}
+
+		keys := make(map[string]string, len(names))
+		m := reflect.MakeMapWithSize(t, len(names))
+		for _, name := range names {
+			key := normalizeKey(name, keyCase)
+			if other, ok := keys[key]; ok {
+				return fmt.Errorf("env %s*: %s and %s both map to key %q", prefix, prefix+other, prefix+name, key)
+			}
+			keys[key] = name
+
+			elem := reflect.New(t.Elem()).Elem()
+			if err := walkElem(elem, key, name); err != nil {
+				return err
+			}
+			mapKey := reflect.ValueOf(key).Convert(t.Key())
+			l.afterLoad = append(l.afterLoad, func() { m.SetMapIndex(mapKey, elem) })
+		}
+		if field.CanSet() {
+			field.Set(m)
+		}
+		return nil
+
+	default:
+		keys := field.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			elem := reflect.New(t.Elem()).Elem()
+			elem.Set(field.MapIndex(key))
+			name := key.String()
+			if keyCase != "keep" {
+				name = strings.ToUpper(name)
+			}
+			if err := walkElem(elem, key.String(), name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// discoverKeys returns the sorted element names of a map of structs of type elem
+// stored in variables named <prefix><name>_<field>. If a variable matches
+// several fields, the longest field name wins.
+func (l *loader) discoverKeys(elem reflect.Type, prefix string) ([]string, error) {
+	schema := &loader{naming: l.naming, indexPlaceholder: l.indexPlaceholder, keyPlaceholder: l.keyPlaceholder}
+	var specs []fieldSpec
+	if err := schema.walkFields(reflect.New(elem).Elem(), "", "", &specs); err != nil {
+		return nil, err
+	}
+
+	var suffixes []string
+	for _, spec := range specs {
+		for _, name := range spec.names() {
+			suffixes = append(suffixes, "_"+name, "_"+name+FileSuffix)
+		}
+	}
+	sort.Slice(suffixes, func(i, j int) bool { return len(suffixes[i]) > len(suffixes[j]) })
+
+	seen := make(map[string]bool)
+	for _, key := range l.source.Keys() {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		for _, suffix := range suffixes {
+			if name, ok := strings.CutSuffix(rest, suffix); ok && name != "" {
+				seen[name] = true
+				break
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// normalizeKey converts the name of a map element found in the source to its key.
+func normalizeKey(name, keyCase string) string {
+	switch keyCase {
+	case "lower":
+		return strings.ToLower(name)
+	case "upper":
+		return strings.ToUpper(name)
+	default:
+		return name
+	}
+}
@@ -0,0 +1,199 @@
+package envconfig
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type dbConfig struct {
+	Host     string `env:"HOST" required:"true"`
+	ReadHost string `env:"READ_HOST"`
+	Port     int    `env:"PORT" default:"5432"`
+}
+
+type mapConfig struct {
+	DBs map[string]dbConfig `prefix:"DB_"`
+}
+
+type upperMapConfig struct {
+	DBs map[string]dbConfig `prefix:"DB_" keycase:"upper"`
+}
+
+func TestLoadStructMapOfStructs(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     MapSource
+		want    map[string]dbConfig
+		wantErr string
+	}{
+		{
+			name: "no elements",
+			src:  MapSource{"OTHER": "x"},
+			want: nil,
+		},
+		{
+			name: "two elements",
+			src: MapSource{
+				"DB_PRIMARY_HOST":   "db1",
+				"DB_PRIMARY_PORT":   "5433",
+				"DB_ANALYTICS_HOST": "db2",
+			},
+			want: map[string]dbConfig{
+				"primary":   {Host: "db1", Port: 5433},
+				"analytics": {Host: "db2", Port: 5432},
+			},
+		},
+		{
+			name: "longest field name wins",
+			src: MapSource{
+				"DB_MAIN_HOST":      "db1",
+				"DB_MAIN_READ_HOST": "replica1",
+			},
+			want: map[string]dbConfig{
+				"main": {Host: "db1", ReadHost: "replica1", Port: 5432},
+			},
+		},
+		{
+			name: "names with underscores",
+			src: MapSource{
+				"DB_EU_WEST_HOST": "db1",
+			},
+			want: map[string]dbConfig{
+				"eu_west": {Host: "db1", Port: 5432},
+			},
+		},
+		{
+			name: "element validation names the variable",
+			src: MapSource{
+				"DB_PRIMARY_PORT": "5433",
+			},
+			wantErr: "env DB_PRIMARY_HOST: required variable is not set",
+		},
+		{
+			name: "keys collide after normalisation",
+			src: MapSource{
+				"DB_Primary_HOST": "db1",
+				"DB_PRIMARY_HOST": "db2",
+			},
+			wantErr: `env DB_*: DB_PRIMARY and DB_Primary both map to key "primary"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg mapConfig
+			err := LoadStruct(&cfg, WithSource(tt.src))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadStruct() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+
+			if len(cfg.DBs) != len(tt.want) || (tt.want == nil) != (cfg.DBs == nil) {
+				t.Fatalf("DBs = %+v, want %+v", cfg.DBs, tt.want)
+			}
+			for key, want := range tt.want {
+				if got, ok := cfg.DBs[key]; !ok || got != want {
+					t.Errorf("DBs[%q] = %+v, want %+v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadStructMapKeyCase(t *testing.T) {
+	var cfg upperMapConfig
+	if err := LoadStruct(&cfg, WithSource(MapSource{"DB_Primary_HOST": "db1"})); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if _, ok := cfg.DBs["PRIMARY"]; !ok {
+		t.Errorf("DBs = %+v, want key PRIMARY", cfg.DBs)
+	}
+
+	var bad struct {
+		DBs map[string]dbConfig `prefix:"DB_" keycase:"title"`
+	}
+	if err := LoadStruct(&bad); err == nil || !strings.Contains(err.Error(), `invalid keycase tag "title"`) {
+		t.Errorf("LoadStruct() error = %v, want invalid keycase", err)
+	}
+}
+
+func TestLoadStructMapOfStructsWithoutPrefix(t *testing.T) {
+	var cfg struct {
+		DBs map[string]dbConfig
+	}
+	src := WithSource(MapSource{"DOCKER_HOST": "x"})
+
+	want := "field DBs: map of structs requires a prefix tag"
+	if err := LoadStruct(&cfg, src); err == nil || err.Error() != want {
+		t.Errorf("LoadStruct() error = %v, want %q", err, want)
+	}
+	if cfg.DBs != nil {
+		t.Errorf("DBs = %+v, want nil", cfg.DBs)
+	}
+	if err := CheckStruct(&cfg); err == nil || err.Error() != want {
+		t.Errorf("CheckStruct() error = %v, want %q", err, want)
+	}
+}
+
+func TestLoadStructMapOfStructsStrict(t *testing.T) {
+	src := MapSource{
+		"DB_PRIMARY_HOST": "db1",
+		"DB_PRIMARY_PROT": "5433",
+	}
+
+	var cfg mapConfig
+	err := LoadStruct(&cfg, WithSource(src), Strict("DB_"))
+	if err == nil || !strings.Contains(err.Error(), "unknown variable DB_PRIMARY_PROT (did you mean DB_PRIMARY_PORT?)") {
+		t.Errorf("LoadStruct() error = %v", err)
+	}
+}
+
+func TestUsageAndDumpMapOfStructs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Usage(&buf, &mapConfig{}); err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "DB_<NAME>_HOST") {
+		t.Errorf("Usage() output missing DB_<NAME>_HOST:\n%s", out)
+	}
+
+	cfg := mapConfig{DBs: map[string]dbConfig{"primary": {Host: "db1", Port: 1}}}
+	entries, err := Dump(&cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Path+" "+e.Env+"="+e.Value)
+	}
+	want := "DBs[primary].Host DB_PRIMARY_HOST=db1,DBs[primary].ReadHost DB_PRIMARY_READ_HOST=,DBs[primary].Port DB_PRIMARY_PORT=1"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Dump() = %v, want %s", got, want)
+	}
+}
+
+func TestWithSource(t *testing.T) {
+	t.Setenv("SOURCE_PORT", "1")
+
+	port, ok, err := Lookup[int]("SOURCE_PORT", WithSource(MapSource{"SOURCE_PORT": "2"}))
+	if err != nil || !ok || port != 2 {
+		t.Errorf("Lookup() = %v, %v, %v, want 2, true, nil", port, ok, err)
+	}
+
+	var md Metadata
+	var cfg struct {
+		Port int `env:"SOURCE_PORT"`
+	}
+	if err := LoadStruct(&cfg, WithSource(MapSource{"SOURCE_PORT": "3"}), WithMetadata(&md)); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if origin, _ := md.Origin("Port"); cfg.Port != 3 || origin.Kind != SourceEnv {
+		t.Errorf("LoadStruct() = %d from %v, want 3 from env", cfg.Port, origin)
+	}
+}
@@ -0,0 +1,68 @@
+package envconfig
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WithAutoNames makes LoadStruct derive the variable name of every exported
+// field without an "env" tag from the field name with UpperSnakeCase.
+// Nested structs without a "prefix" tag get the derived name of the struct
+// field followed by "_" as their prefix. Fields tagged with env:"-" are skipped.
+//
+// Example:
+//
+//	type Config struct {
+//	    MaxIdleConns int                 // MAX_IDLE_CONNS
+//	    HTTPPort     int `default:"80"`  // HTTP_PORT
+//	    DB           struct {
+//	        URL string                    // DB_URL
+//	    }
+//	    Internal string `env:"-"`        // not loaded
+//	}
+func WithAutoNames() Option {
+	return WithNaming(UpperSnakeCase)
+}
+
+// WithNaming is like WithAutoNames, but derives names with the given strategy.
+// The strategy is applied to every field name separately, including the names
+// of nested structs, so it must not add a prefix; use WithPrefix for that.
+func WithNaming(strategy func(fieldName string) string) Option {
+	return func(l *loader) {
+		l.naming = strategy
+	}
+}
+
+// WithPrefix prepends prefix to the name of every variable, derived or
+// given in an "env" tag, as if cfg was a nested struct with that "prefix" tag.
+//
+// Example:
+//
+//	// APP_PORT, APP_DB_URL
+//	err := envconfig.LoadStruct(&cfg, envconfig.WithPrefix("APP_"), envconfig.WithAutoNames())
+func WithPrefix(prefix string) Option {
+	return func(l *loader) {
+		l.prefix = prefix
+	}
+}
+
+// UpperSnakeCase converts a Go field name to an environment variable name:
+// MaxIdleConns becomes MAX_IDLE_CONNS, HTTPPort becomes HTTP_PORT and
+// APIKeyID becomes API_KEY_ID. It is the naming strategy of WithAutoNames.
+func UpperSnakeCase(name string) string {
+	runes := []rune(name)
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,111 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpperSnakeCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Port", "PORT"},
+		{"MaxIdleConns", "MAX_IDLE_CONNS"},
+		{"HTTPPort", "HTTP_PORT"},
+		{"URL", "URL"},
+		{"DatabaseURL", "DATABASE_URL"},
+		{"APIKeyID", "API_KEY_ID"},
+		{"Retry2Count", "RETRY2_COUNT"},
+		{"already_snake", "ALREADY_SNAKE"},
+	}
+	for _, tt := range tests {
+		if got := UpperSnakeCase(tt.name); got != tt.want {
+			t.Errorf("UpperSnakeCase(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+type autoConfig struct {
+	MaxIdleConns int `default:"4"`
+	HTTPPort     int
+	Explicit     string `env:"AUTO_EXPLICIT"`
+	Ignored      string `env:"-"`
+	internal     string
+	DB           struct {
+		URL string
+	}
+	Cache struct {
+		TTL int
+	} `prefix:"REDIS_"`
+}
+
+func TestLoadStructAutoNames(t *testing.T) {
+	t.Setenv("HTTP_PORT", "8443")
+	t.Setenv("AUTO_EXPLICIT", "x")
+	t.Setenv("IGNORED", "should not be read")
+	t.Setenv("DB_URL", "postgres://localhost")
+	t.Setenv("REDIS_TTL", "60")
+
+	var cfg autoConfig
+	if err := LoadStruct(&cfg, WithAutoNames()); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.MaxIdleConns != 4 || cfg.HTTPPort != 8443 || cfg.Explicit != "x" {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+	if cfg.Ignored != "" || cfg.internal != "" {
+		t.Errorf("LoadStruct() loaded skipped fields: %+v", cfg)
+	}
+	if cfg.DB.URL != "postgres://localhost" || cfg.Cache.TTL != 60 {
+		t.Errorf("LoadStruct() nested = %+v", cfg)
+	}
+}
+
+func TestLoadStructWithoutAutoNames(t *testing.T) {
+	t.Setenv("HTTP_PORT", "8443")
+
+	var cfg autoConfig
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if cfg.HTTPPort != 0 {
+		t.Errorf("HTTPPort = %v, want 0 without WithAutoNames", cfg.HTTPPort)
+	}
+}
+
+func TestWithNaming(t *testing.T) {
+	var cfg autoConfig
+	var sb strings.Builder
+	if err := Usagef(&sb, &cfg, `{{range .}}{{.Name}} {{end}}`, WithNaming(strings.ToLower)); err != nil {
+		t.Fatalf("Usagef() error = %v", err)
+	}
+
+	want := "maxidleconns httpport AUTO_EXPLICIT db_url REDIS_ttl "
+	if sb.String() != want {
+		t.Errorf("Usagef() = %q, want %q", sb.String(), want)
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	var cfg autoConfig
+	var sb strings.Builder
+	if err := Usagef(&sb, &cfg, `{{range .}}{{.Name}} {{end}}`, WithPrefix("APP_"), WithAutoNames()); err != nil {
+		t.Fatalf("Usagef() error = %v", err)
+	}
+
+	want := "APP_MAX_IDLE_CONNS APP_HTTP_PORT APP_AUTO_EXPLICIT APP_DB_URL APP_REDIS_TTL "
+	if sb.String() != want {
+		t.Errorf("Usagef() = %q, want %q", sb.String(), want)
+	}
+
+	t.Setenv("APP_DB_URL", "postgres://localhost")
+	t.Setenv("APP_AUTO_EXPLICIT", "x")
+	if err := LoadStruct(&cfg, WithPrefix("APP_"), WithAutoNames()); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if cfg.DB.URL != "postgres://localhost" || cfg.Explicit != "x" {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+}
@@ -0,0 +1,109 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// HostPort is a network address of the form "host:port", e.g. "db.local:5432",
+// "[::1]:8080" or ":8080". The port must be a number from 0 to 65535.
+//
+// Example:
+//
+//	type Config struct {
+//	    Listen envconfig.HostPort `env:"LISTEN" default:":8080"`
+//	}
+type HostPort struct {
+	Host string
+	Port uint16
+}
+
+// ParseHostPort parses s as a "host:port" address.
+func ParseHostPort(s string) (HostPort, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		var addrErr *net.AddrError
+		if errors.As(err, &addrErr) {
+			return HostPort{}, fmt.Errorf("invalid host:port: %s", addrErr.Err)
+		}
+		return HostPort{}, fmt.Errorf("invalid host:port: %w", err)
+	}
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return HostPort{}, fmt.Errorf("invalid host:port: invalid port %q", port)
+	}
+	return HostPort{Host: host, Port: uint16(n)}, nil
+}
+
+// String returns the address in the form ParseHostPort accepts.
+func (hp HostPort) String() string {
+	return net.JoinHostPort(hp.Host, strconv.Itoa(int(hp.Port)))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (hp HostPort) MarshalText() ([]byte, error) {
+	return []byte(hp.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (hp *HostPort) UnmarshalText(text []byte) error {
+	v, err := ParseHostPort(string(text))
+	if err != nil {
+		return err
+	}
+	*hp = v
+	return nil
+}
+
+// setURL parses a url.URL and checks its scheme against the "schemes" tag.
+// The error never contains the URL itself, which may hold a password.
+func setURL(field reflect.Value, value string, opts decodeOptions) error {
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if len(opts.schemes) > 0 {
+		allowed := false
+		for _, scheme := range opts.schemes {
+			if strings.EqualFold(u.Scheme, scheme) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("URL scheme %q is not allowed (allowed: %s)", u.Scheme, strings.Join(opts.schemes, ", "))
+		}
+	}
+
+	field.Set(reflect.ValueOf(*u))
+	return nil
+}
+
+// setIP parses a net.IP in IPv4 or IPv6 notation.
+func setIP(field reflect.Value, value string, _ decodeOptions) error {
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return fmt.Errorf("invalid IP address %q", value)
+	}
+	field.Set(reflect.ValueOf(ip))
+	return nil
+}
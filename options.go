@@ -0,0 +1,165 @@
+package envconfig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Option configures LoadStruct.
+type Option func(*loader)
+
+// WithMetadata makes LoadStruct record the origin of every loaded field in md.
+// Passed to Dump, it makes Dump report the recorded origins.
+func WithMetadata(md *Metadata) Option {
+	return func(l *loader) {
+		l.metadata = md
+	}
+}
+
+// walkMode tells collectFields how to expand slices and maps of structs.
+type walkMode int
+
+const (
+	// walkSchema describes the struct with one placeholder element per slice.
+	walkSchema walkMode = iota
+	// walkLoad sizes slices and maps from the variables in the source.
+	walkLoad
+	// walkValue uses the elements the slices and maps already hold.
+	walkValue
+)
+
+// loader holds the settings of a single LoadStruct call.
+type loader struct {
+	mode walkMode
+	// indexPlaceholder and keyPlaceholder stand for the index of a slice
+	// element and the key of a map element in walkSchema mode.
+	indexPlaceholder string
+	keyPlaceholder   string
+	// afterLoad stores loaded map elements into their maps.
+	afterLoad []func()
+
+	source   Source
+	metadata *Metadata
+	warn     func(err error)
+	empty    EmptyPolicy
+	naming   func(fieldName string) string
+	prefix   string
+	unquote  bool
+
+	strict       bool
+	strictPrefix string
+	strictWarn   bool
+}
+
+func newLoader(opts []Option) *loader {
+	l := &loader{source: Environment, warn: defaultWarn, indexPlaceholder: "0", keyPlaceholder: "NAME"}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// resolve returns the raw value of the field described by spec and its origin.
+// The lookup order is: the variable itself, its aliases in the order they are
+// listed, the <NAME>_FILE variable, the "default" tag. Values of variables
+// not read from a .env file are unquoted if the field or loader asks for it;
+// values read from variables and files then pass through the field's
+// "transform" tag. If the variable and its
+// aliases hold different values, resolve fails. If nothing provides a value,
+// the origin is SourceNone. Empty variables and empty <NAME>_FILE files are
+// handled according to the field's empty policy.
+func (l *loader) resolve(spec fieldSpec) (string, Origin, error) {
+	var (
+		value  string
+		origin Origin
+		found  bool
+	)
+	for _, name := range spec.names() {
+		v, o, ok, err := l.lookupVar(spec, name)
+		if err != nil {
+			return "", o, err
+		}
+		if !ok {
+			continue
+		}
+		if !found {
+			value, origin, found = v, o, true
+			continue
+		}
+		if v != value {
+			return "", origin, fmt.Errorf("env %s: conflicting values in %s and %s", spec.envName, origin.Key, name)
+		}
+	}
+	if found {
+		return value, origin, nil
+	}
+
+	fileKey := spec.envName + FileSuffix
+	if path, exists := l.source.Lookup(fileKey); exists {
+		origin := Origin{Kind: SourceFile, Key: fileKey, File: path}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", origin, fmt.Errorf("env %s: %w", fileKey, err)
+		}
+		value, err := applyTransforms(strings.TrimRight(string(data), "\r\n"), spec.transform)
+		if err != nil {
+			return "", origin, fmt.Errorf("env %s: %w", fileKey, err)
+		}
+		// An empty file is treated like an empty variable.
+		switch {
+		case value != "" || l.emptyPolicy(spec) == EmptyAsValue:
+			return value, origin, nil
+		case l.emptyPolicy(spec) == EmptyIsError:
+			return "", origin, fmt.Errorf("env %s: %w", fileKey, ErrEmptyValue)
+		}
+	}
+
+	if spec.hasDefault {
+		return spec.defaultValue, Origin{Kind: SourceDefault}, nil
+	}
+
+	return "", Origin{Kind: SourceNone}, nil
+}
+
+// lookupVar looks up name, which is the variable of spec or one of its aliases.
+// The third result is false if the variable is not set, or is empty and
+// the empty policy treats it as unset.
+func (l *loader) lookupVar(spec fieldSpec, name string) (string, Origin, bool, error) {
+	value, exists := l.source.Lookup(name)
+	if !exists {
+		return "", Origin{}, false, nil
+	}
+
+	origin, ok := Origin{}, false
+	if l.fromEnvironment() {
+		origin, ok = dotenvOrigin(name, value)
+	}
+	if !ok {
+		origin = Origin{Kind: SourceEnv, Key: name}
+	}
+	// Values read from a .env file were already unquoted by Load.
+	if (l.unquote || spec.unquote) && origin.File == "" {
+		unquoted, err := unquoteValue(value)
+		if err != nil {
+			return "", origin, false, fmt.Errorf("env %s: %w", name, err)
+		}
+		value = unquoted
+	}
+	value, err := applyTransforms(value, spec.transform)
+	if err != nil {
+		return "", origin, false, fmt.Errorf("env %s: %w", name, err)
+	}
+	if name != spec.envName {
+		origin.Kind = SourceAlias
+	}
+
+	switch {
+	case value != "" || l.emptyPolicy(spec) == EmptyAsValue:
+		return value, origin, true, nil
+	case l.emptyPolicy(spec) == EmptyIsError:
+		return "", origin, false, fmt.Errorf("env %s: %w", name, ErrEmptyValue)
+	default:
+		return "", origin, false, nil
+	}
+}
@@ -0,0 +1,152 @@
+package envconfig
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Kinds of value sources reported in Origin.Kind and DumpEntry.Source.
+const (
+	// SourceEnv means the value was read from the process environment.
+	SourceEnv = "env"
+	// SourceDotEnv means the value was read from a .env file by Load.
+	SourceDotEnv = "dotenv"
+	// SourceAlias means the value was read from one of the names listed in
+	// the "aliases" tag. File and Line are set if Load read it from a .env file.
+	SourceAlias = "alias"
+	// SourceFile means the value was read from the file named by
+	// the <NAME>_FILE variable, e.g. a Docker or Kubernetes secret.
+	SourceFile = "file"
+	// SourceDefault means the value was taken from the "default" tag.
+	SourceDefault = "default"
+	// SourceNone means no source provided a value.
+	SourceNone = "none"
+)
+
+// FileSuffix is appended to a variable name to look up a file holding its value.
+// If DB_PASSWORD is not set but DB_PASSWORD_FILE is, LoadStruct reads the value
+// from the file DB_PASSWORD_FILE points to, with trailing newlines removed.
+const FileSuffix = "_FILE"
+
+// Origin describes where the value of a field came from.
+type Origin struct {
+	// Kind is one of SourceEnv, SourceDotEnv, SourceAlias, SourceFile,
+	// SourceDefault or SourceNone.
+	Kind string
+	// Key is the environment variable that provided the value. For SourceFile
+	// it is the <NAME>_FILE variable, for SourceAlias the alias.
+	// It is empty for SourceDefault and SourceNone.
+	Key string
+	// File is the .env file for SourceDotEnv and the secret file for SourceFile.
+	File string
+	// Line is the line of Key in File for SourceDotEnv and SourceAlias.
+	Line int
+}
+
+// String returns a short description of the origin, e.g. "env", ".env:14",
+// "alias OLD_NAME" or "file /run/secrets/db_password".
+func (o Origin) String() string {
+	switch o.Kind {
+	case SourceAlias:
+		if o.File != "" {
+			return fmt.Sprintf("alias %s (%s:%d)", o.Key, o.File, o.Line)
+		}
+		return SourceAlias + " " + o.Key
+	case SourceDotEnv:
+		if o.Line > 0 {
+			return fmt.Sprintf("%s:%d", o.File, o.Line)
+		}
+		return o.File
+	case SourceFile:
+		return SourceFile + " " + o.File
+	default:
+		return o.Kind
+	}
+}
+
+// Metadata records the origin of every field loaded by LoadStruct.
+// Pass it to LoadStruct with WithMetadata.
+//
+// Example:
+//
+//	var md envconfig.Metadata
+//	if err := envconfig.LoadStruct(&cfg, envconfig.WithMetadata(&md)); err != nil {
+//	    log.Fatal(err)
+//	}
+//	origin, _ := md.Origin("DB.Host")
+//	fmt.Println(origin) // .env:3
+type Metadata struct {
+	paths   []string
+	origins map[string]Origin
+}
+
+// Origin returns the origin of the field with the given Go path, e.g. "DB.Host".
+// The second result is false if the field was not loaded.
+func (m *Metadata) Origin(path string) (Origin, bool) {
+	origin, ok := m.origins[path]
+	return origin, ok
+}
+
+// Paths returns the Go paths of all loaded fields in declaration order.
+func (m *Metadata) Paths() []string {
+	return append([]string(nil), m.paths...)
+}
+
+func (m *Metadata) record(path string, origin Origin) {
+	if m.origins == nil {
+		m.origins = make(map[string]Origin)
+	}
+	if _, ok := m.origins[path]; !ok {
+		m.paths = append(m.paths, path)
+	}
+	m.origins[path] = origin
+}
+
+// dotenvEntry records a variable read by Load.
+type dotenvEntry struct {
+	value string
+	file  string
+	line  int
+	// applied is false if the variable was already set in the environment,
+	// so Load left it unchanged.
+	applied bool
+}
+
+var (
+	dotenvMu      sync.RWMutex
+	dotenvEntries = make(map[string]dotenvEntry)
+)
+
+// recordDotEnv remembers that key was read from file, and whether
+// the environment variable was set to value.
+func recordDotEnv(key, value, file string, line int, applied bool) {
+	dotenvMu.Lock()
+	defer dotenvMu.Unlock()
+	dotenvEntries[key] = dotenvEntry{value: value, file: file, line: line, applied: applied}
+}
+
+// dotenvKeys returns the keys of all variables read by Load
+// together with their location.
+func dotenvKeys() map[string]Origin {
+	dotenvMu.RLock()
+	defer dotenvMu.RUnlock()
+
+	keys := make(map[string]Origin, len(dotenvEntries))
+	for key, entry := range dotenvEntries {
+		keys[key] = Origin{Kind: SourceDotEnv, Key: key, File: entry.file, Line: entry.line}
+	}
+	return keys
+}
+
+// dotenvOrigin returns the .env origin of key if the variable still holds
+// the value Load set it to.
+func dotenvOrigin(key, value string) (Origin, bool) {
+	dotenvMu.RLock()
+	defer dotenvMu.RUnlock()
+
+	entry, ok := dotenvEntries[key]
+	if !ok || !entry.applied || entry.value != value {
+		return Origin{}, false
+	}
+	return Origin{Kind: SourceDotEnv, Key: key, File: entry.file, Line: entry.line}, true
+}
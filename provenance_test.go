@@ -0,0 +1,163 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadStructMetadata(t *testing.T) {
+	dir := t.TempDir()
+
+	envFile := filepath.Join(dir, "test.env")
+	content := "# comment\nTEST_ORIGIN_HOST=db.local\n\nexport TEST_ORIGIN_PORT=5433\n"
+	if err := os.WriteFile(envFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	secretFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(secretFile, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvFileKey, envFile)
+	t.Setenv("TEST_ORIGIN_USER", "admin")
+	t.Setenv("TEST_ORIGIN_PASSWORD_FILE", secretFile)
+	for _, key := range []string{"TEST_ORIGIN_HOST", "TEST_ORIGIN_PORT"} {
+		os.Unsetenv(key)
+		defer os.Unsetenv(key)
+	}
+
+	if err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var cfg struct {
+		DB struct {
+			Host     string `env:"HOST"`
+			Port     int    `env:"PORT"`
+			User     string `env:"USER"`
+			Password string `env:"PASSWORD"`
+			Name     string `env:"NAME" default:"app"`
+			Schema   string `env:"SCHEMA"`
+		} `prefix:"TEST_ORIGIN_"`
+	}
+	var md Metadata
+	if err := LoadStruct(&cfg, WithMetadata(&md)); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.DB.Password != "hunter2" {
+		t.Errorf("DB.Password = %q, want hunter2", cfg.DB.Password)
+	}
+
+	tests := []struct {
+		path string
+		want Origin
+	}{
+		{"DB.Host", Origin{Kind: SourceDotEnv, Key: "TEST_ORIGIN_HOST", File: envFile, Line: 2}},
+		{"DB.Port", Origin{Kind: SourceDotEnv, Key: "TEST_ORIGIN_PORT", File: envFile, Line: 4}},
+		{"DB.User", Origin{Kind: SourceEnv, Key: "TEST_ORIGIN_USER"}},
+		{"DB.Password", Origin{Kind: SourceFile, Key: "TEST_ORIGIN_PASSWORD_FILE", File: secretFile}},
+		{"DB.Name", Origin{Kind: SourceDefault}},
+		{"DB.Schema", Origin{Kind: SourceNone}},
+	}
+	for _, tt := range tests {
+		got, ok := md.Origin(tt.path)
+		if !ok {
+			t.Errorf("Origin(%q) not recorded", tt.path)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Origin(%q) = %+v, want %+v", tt.path, got, tt.want)
+		}
+	}
+
+	if got := len(md.Paths()); got != len(tests) {
+		t.Errorf("len(Paths()) = %d, want %d", got, len(tests))
+	}
+	if _, ok := md.Origin("DB.Missing"); ok {
+		t.Error("Origin(\"DB.Missing\") ok = true, want false")
+	}
+}
+
+func TestOriginString(t *testing.T) {
+	tests := []struct {
+		origin Origin
+		want   string
+	}{
+		{Origin{Kind: SourceEnv, Key: "HOST"}, "env"},
+		{Origin{Kind: SourceDotEnv, Key: "HOST", File: ".env", Line: 14}, ".env:14"},
+		{Origin{Kind: SourceFile, Key: "PASSWORD_FILE", File: "/run/secrets/pw"}, "file /run/secrets/pw"},
+		{Origin{Kind: SourceDefault}, "default"},
+	}
+	for _, tt := range tests {
+		if got := tt.origin.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestLoadStructFileSecretError(t *testing.T) {
+	t.Setenv("TEST_MISSING_SECRET_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	var cfg struct {
+		Secret string `env:"TEST_MISSING_SECRET"`
+	}
+	if err := LoadStruct(&cfg); err == nil {
+		t.Error("LoadStruct() error = nil, want error for unreadable secret file")
+	}
+}
+
+func TestLoadStructEmptySecretFile(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_EMPTY_SECRET_FILE", empty)
+
+	t.Run("required", func(t *testing.T) {
+		var cfg struct {
+			Secret string `env:"TEST_EMPTY_SECRET" required:"true"`
+		}
+		err := LoadStruct(&cfg)
+		if err == nil || err.Error() != "env TEST_EMPTY_SECRET: required variable is not set" {
+			t.Errorf("LoadStruct() error = %v, want required variable is not set", err)
+		}
+	})
+
+	t.Run("notEmpty", func(t *testing.T) {
+		var cfg struct {
+			Secret string `env:"TEST_EMPTY_SECRET" notEmpty:"true"`
+		}
+		if err := LoadStruct(&cfg); !errors.Is(err, ErrEmptyValue) {
+			t.Errorf("LoadStruct() error = %v, want ErrEmptyValue", err)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		var cfg struct {
+			Secret string `env:"TEST_EMPTY_SECRET" default:"fallback"`
+		}
+		var md Metadata
+		if err := LoadStruct(&cfg, WithMetadata(&md)); err != nil {
+			t.Fatalf("LoadStruct() error = %v", err)
+		}
+		if origin, _ := md.Origin("Secret"); cfg.Secret != "fallback" || origin.Kind != SourceDefault {
+			t.Errorf("Secret = %q from %v, want fallback from default", cfg.Secret, origin)
+		}
+	})
+
+	t.Run("allowEmpty", func(t *testing.T) {
+		var cfg struct {
+			Secret string `env:"TEST_EMPTY_SECRET" default:"fallback" allowEmpty:"true"`
+		}
+		var md Metadata
+		if err := LoadStruct(&cfg, WithMetadata(&md)); err != nil {
+			t.Fatalf("LoadStruct() error = %v", err)
+		}
+		if origin, _ := md.Origin("Secret"); cfg.Secret != "" || origin.Kind != SourceFile {
+			t.Errorf("Secret = %q from %v, want empty from file", cfg.Secret, origin)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package envconfig
+
+import (
+	"errors"
+	"strings"
+)
+
+// errUnterminatedQuote is returned for a value that opens a quote but does not close it.
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// WithUnquote makes LoadStruct unquote the values of all fields the way
+// a .env file would, as if unquote:"true" was set on every field.
+// Lookup and GetAs unquote the value they read.
+// Some orchestrators pass values with surrounding quotes and a literal \n,
+// e.g. PEM keys; with this option they load the same as from a .env file.
+// Unquoted values, such as Windows paths, are left as they are.
+func WithUnquote() Option {
+	return func(l *loader) {
+		l.unquote = true
+	}
+}
+
+// unquoteValue interprets a raw value with .env quoting rules:
+// a value in single quotes is taken literally, a value in double quotes
+// has its escape sequences expanded (see unescape), and an unquoted value
+// is returned unchanged. Whitespace around a quoted value is ignored.
+func unquoteValue(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return value, nil
+	}
+
+	switch q := trimmed[0]; q {
+	case '\'', '"':
+		if len(trimmed) < 2 || trimmed[len(trimmed)-1] != q || (q == '"' && escapedAt(trimmed, len(trimmed)-1)) {
+			return "", errUnterminatedQuote
+		}
+		inner := trimmed[1 : len(trimmed)-1]
+		if q == '\'' {
+			return inner, nil
+		}
+		return unescape(inner), nil
+	default:
+		return value, nil
+	}
+}
+
+// escapedAt reports whether the byte at i is preceded by an odd number of backslashes.
+func escapedAt(s string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// unescape expands the escape sequences of double-quoted .env values:
+// \n, \r, \t, \\, \", \', \$ and \`. Other backslashes are kept as is.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			if r, ok := escapeChar(s[i+1]); ok {
+				sb.WriteByte(r)
+				i++
+				continue
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// escapeChar returns the character the escape sequence \c stands for
+// in a double-quoted .env value.
+func escapeChar(c byte) (byte, bool) {
+	switch c {
+	case 'n':
+		return '\n', true
+	case 'r':
+		return '\r', true
+	case 't':
+		return '\t', true
+	case '\\', '"', '\'', '$', '`':
+		return c, true
+	}
+	return 0, false
+}
@@ -0,0 +1,141 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnquoteValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: `plain`, want: "plain"},
+		{in: `"quoted value"`, want: "quoted value"},
+		{in: `  "padded"  `, want: "padded"},
+		{in: `'single \n literal'`, want: `single \n literal`},
+		{in: `"line1\nline2"`, want: "line1\nline2"},
+		{in: `line1\nline2`, want: `line1\nline2`},
+		{in: `C:\new\table`, want: `C:\new\table`},
+		{in: `"say \"hi\" \$HOME \\ \t"`, want: "say \"hi\" $HOME \\ \t"},
+		{in: `C:\path`, want: `C:\path`},
+		{in: `""`, want: ""},
+		{in: `"open`, wantErr: true},
+		{in: `'open`, wantErr: true},
+		{in: `"escaped end\"`, wantErr: true},
+		{in: `"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := unquoteValue(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unquoteValue(%q) = %q, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("unquoteValue(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnescapeRoundTrip(t *testing.T) {
+	for _, value := range []string{"a b", "line1\nline2\r\n", `C:\dir "x" $HOME`, "tab\there"} {
+		quoted := quoteEnvValue(value)
+		got, err := unquoteValue(quoted)
+		if err != nil || got != value {
+			t.Errorf("unquoteValue(quoteEnvValue(%q)) = %q, %v", value, got, err)
+		}
+	}
+}
+
+const testPEM = "-----BEGIN KEY-----\nMIIB\n-----END KEY-----"
+
+func TestLoadStructUnquote(t *testing.T) {
+	resetDotEnv(t)
+	t.Setenv("UNQUOTE_KEY", `"-----BEGIN KEY-----\nMIIB\n-----END KEY-----"`)
+	t.Setenv("UNQUOTE_NAME", `'my app'`)
+	t.Setenv("UNQUOTE_RAW", `"kept"`)
+
+	var cfg struct {
+		Key  string `env:"UNQUOTE_KEY" unquote:"true"`
+		Name string `env:"UNQUOTE_NAME" unquote:"true"`
+		Raw  string `env:"UNQUOTE_RAW"`
+	}
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if cfg.Key != testPEM || cfg.Name != "my app" || cfg.Raw != `"kept"` {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+
+	if err := LoadStruct(&cfg, WithUnquote()); err != nil {
+		t.Fatalf("LoadStruct(WithUnquote) error = %v", err)
+	}
+	if cfg.Raw != "kept" {
+		t.Errorf("LoadStruct(WithUnquote) Raw = %q, want %q", cfg.Raw, "kept")
+	}
+
+	t.Setenv("UNQUOTE_NAME", `"unterminated`)
+	err := LoadStruct(&cfg)
+	if err == nil || err.Error() != "env UNQUOTE_NAME: unterminated quote" {
+		t.Errorf("LoadStruct() error = %v, want unterminated quote", err)
+	}
+}
+
+func TestLoadStructUnquoteMatchesDotEnv(t *testing.T) {
+	resetDotEnv(t)
+	envFile := filepath.Join(t.TempDir(), ".env")
+	content := `UNQUOTE_FILE_KEY="-----BEGIN KEY-----\nMIIB\n-----END KEY-----"` + "\n"
+	if err := os.WriteFile(envFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvFileKey, envFile)
+	t.Setenv("UNQUOTE_FILE_KEY", "")
+	os.Unsetenv("UNQUOTE_FILE_KEY")
+	if err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var cfg struct {
+		Key string `env:"UNQUOTE_FILE_KEY"`
+	}
+	if err := LoadStruct(&cfg, WithUnquote()); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if cfg.Key != testPEM {
+		t.Errorf("Key = %q, want %q", cfg.Key, testPEM)
+	}
+}
+
+func TestLookupUnquote(t *testing.T) {
+	resetDotEnv(t)
+	t.Setenv("UNQUOTE_LOOKUP", `"a\nb"`)
+	t.Setenv("UNQUOTE_LOOKUP_EMPTY", `""`)
+	t.Setenv("UNQUOTE_LOOKUP_BAD", `"open`)
+
+	got, ok, err := Lookup[string]("UNQUOTE_LOOKUP", WithUnquote())
+	if err != nil || !ok || got != "a\nb" {
+		t.Errorf("Lookup(WithUnquote) = %q, %v, %v, want %q", got, ok, err, "a\nb")
+	}
+	if got := GetAs("UNQUOTE_LOOKUP", "", WithUnquote()); got != "a\nb" {
+		t.Errorf("GetAs(WithUnquote) = %q, want %q", got, "a\nb")
+	}
+	if got := GetAs("UNQUOTE_LOOKUP", ""); got != `"a\nb"` {
+		t.Errorf("GetAs() = %q, want the raw value", got)
+	}
+	if got := GetAs("UNQUOTE_LOOKUP_EMPTY", "default", WithUnquote()); got != "default" {
+		t.Errorf("GetAs(WithUnquote) of \"\" = %q, want default", got)
+	}
+
+	var parseErr *ParseError
+	if _, _, err := Lookup[string]("UNQUOTE_LOOKUP_BAD", WithUnquote()); !errors.As(err, &parseErr) {
+		t.Errorf("Lookup(WithUnquote) error = %v, want *ParseError", err)
+	}
+}
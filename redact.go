@@ -0,0 +1,73 @@
+package envconfig
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// RedactedValue replaces the values of secret fields in everything
+// the package prints: usage, examples, dumps and error messages.
+const RedactedValue = "******"
+
+// redactedError is an error whose message has secret values masked.
+// It unwraps to a cause without the secret (e.g. strconv.ErrSyntax or
+// ErrEmptyValue) rather than to the original error, so the raw value
+// cannot be recovered with errors.As.
+type redactedError struct {
+	msg   string
+	cause error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+
+func (e *redactedError) Unwrap() error { return e.cause }
+
+// redactError returns err with every occurrence of value masked.
+// Parts of value quoted by strconv errors, such as a single slice element,
+// are masked too.
+func redactError(err error, value string) error {
+	if err == nil {
+		return nil
+	}
+
+	secrets := []string{value}
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		secrets = append(secrets, numErr.Num)
+	}
+
+	msg := err.Error()
+	for _, secret := range secrets {
+		if secret != "" {
+			msg = strings.ReplaceAll(msg, secret, RedactedValue)
+		}
+	}
+
+	return &redactedError{msg: msg, cause: redactedCause(err, secrets)}
+}
+
+// redactedCause returns the outermost error in the chain of err whose
+// message contains none of secrets, skipping *strconv.NumError, which
+// holds the value in a field.
+func redactedCause(err error, secrets []string) error {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if _, ok := err.(*strconv.NumError); ok {
+			continue
+		}
+		if !containsSecret(err.Error(), secrets) {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsSecret(msg string, secrets []string) bool {
+	for _, secret := range secrets {
+		if secret != "" && strings.Contains(msg, secret) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package envconfig
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadStructRedactsSecretErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    any
+		opts   []Option
+		env    string
+		value  string
+		wantIs error
+	}{
+		{
+			name: "int value",
+			cfg: &struct {
+				PIN int `env:"TEST_SECRET_PIN" secret:"true"`
+			}{},
+			env:    "TEST_SECRET_PIN",
+			value:  "hunter2",
+			wantIs: strconv.ErrSyntax,
+		},
+		{
+			name: "slice element",
+			cfg: &struct {
+				Codes []int `env:"TEST_SECRET_CODES" secret:"true"`
+			}{},
+			env:    "TEST_SECRET_CODES",
+			value:  "1234,hunter2",
+			wantIs: strconv.ErrSyntax,
+		},
+		{
+			name: "duration element",
+			cfg: &struct {
+				Timeouts []time.Duration `env:"TEST_SECRET_TIMEOUTS" secret:"true"`
+			}{},
+			env:   "TEST_SECRET_TIMEOUTS",
+			value: "1s,hunter2",
+		},
+		{
+			name: "bool element",
+			cfg: &struct {
+				Flags []bool `env:"TEST_SECRET_FLAGS" secret:"true"`
+			}{},
+			env:   "TEST_SECRET_FLAGS",
+			value: "true,hunter2",
+		},
+		{
+			name: "IP element",
+			cfg: &struct {
+				IPs []net.IP `env:"TEST_SECRET_IPS" secret:"true"`
+			}{},
+			env:   "TEST_SECRET_IPS",
+			value: "10.0.0.1,hunter2",
+		},
+		{
+			name: "byte size element",
+			cfg: &struct {
+				Sizes []int64 `env:"TEST_SECRET_SIZES" unit:"bytes" secret:"true"`
+			}{},
+			env:   "TEST_SECRET_SIZES",
+			value: "1KiB,hunter2",
+		},
+		{
+			name: "enum element",
+			cfg: &struct {
+				Modes []string `env:"TEST_SECRET_MODES" enum:"a,b" secret:"true"`
+			}{},
+			env:   "TEST_SECRET_MODES",
+			value: "a,hunter2",
+		},
+		{
+			name: "range element",
+			cfg: &struct {
+				Ports []int `env:"TEST_SECRET_PORTS" secret:"true"`
+			}{},
+			env:   "TEST_SECRET_PORTS",
+			value: "80,9999-1234",
+		},
+		{
+			name: "empty element",
+			cfg: &struct {
+				Codes []int `env:"TEST_SECRET_EMPTY_CODES" secret:"true"`
+			}{},
+			opts:   []Option{WithEmptyPolicy(EmptyIsError)},
+			env:    "TEST_SECRET_EMPTY_CODES",
+			value:  "hunter2,,1",
+			wantIs: ErrEmptyValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+
+			err := LoadStruct(tt.cfg, tt.opts...)
+			if err == nil {
+				t.Fatal("LoadStruct() error = nil, want error")
+			}
+			if strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "9999") {
+				t.Errorf("LoadStruct() error = %q, leaks secret value", err)
+			}
+			if !strings.Contains(err.Error(), tt.env) {
+				t.Errorf("LoadStruct() error = %q, want it to name %s", err, tt.env)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("LoadStruct() error = %q, want it to wrap %v", err, tt.wantIs)
+			}
+			var numErr *strconv.NumError
+			if errors.As(err, &numErr) {
+				t.Errorf("LoadStruct() error exposes %T with value %q", numErr, numErr.Num)
+			}
+		})
+	}
+}
+
+func TestUsageRedactsSecretDefault(t *testing.T) {
+	var cfg struct {
+		Token string `env:"TOKEN" default:"hunter2" secret:"true"`
+	}
+
+	var sb strings.Builder
+	if err := Usage(&sb, &cfg); err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	if strings.Contains(sb.String(), "hunter2") {
+		t.Errorf("Usage() leaks secret default:\n%s", sb.String())
+	}
+	if !strings.Contains(sb.String(), RedactedValue) {
+		t.Errorf("Usage() does not mask secret default:\n%s", sb.String())
+	}
+}
@@ -1,17 +1,79 @@
 package envconfig
 
 import (
+	"encoding"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
-func setValue(field reflect.Value, value string) error {
+// typeDecoders decode types that need more than their kind to be parsed.
+// They are consulted before encoding.TextUnmarshaler and the kind-based
+// decoding in setValue.
+var typeDecoders = map[reflect.Type]func(field reflect.Value, value string, opts decodeOptions) error{
+	reflect.TypeOf(time.Duration(0)):      setDuration,
+	reflect.TypeOf(url.URL{}):             setURL,
+	reflect.TypeOf(net.IP(nil)):           setIP,
+	reflect.TypeOf((*time.Location)(nil)): setLocation,
+	reflect.TypeOf((*regexp.Regexp)(nil)): setRegexp,
+	reflect.TypeOf(os.FileMode(0)):        setFileMode,
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decodeOptions holds the settings that affect how a value is decoded.
+type decodeOptions struct {
+	empty EmptyPolicy
+	// format is the "format" tag of the field, e.g. "json".
+	format string
+	// schemes is the "schemes" tag of a URL field: the allowed URL schemes.
+	schemes []string
+	// unit is the "unit" tag of an integer field, e.g. "bytes".
+	unit string
+	// enum is the "enum" tag, e.g. "fast=1,safe=2,off=0".
+	enum string
+	// secret is set for fields tagged with secret:"true": errors about
+	// list elements have the element masked.
+	secret bool
+}
+
+func setValue(field reflect.Value, value string, opts decodeOptions) error {
 	if !field.CanSet() {
 		return nil
 	}
 
+	if opts.format != "" {
+		return setFormatted(field, value, opts.format)
+	}
+	if opts.unit != "" {
+		return setWithUnit(field, value, opts)
+	}
+	if opts.enum != "" {
+		return setEnum(field, value, opts)
+	}
+
+	if parse, ok := lookupParser(field.Type()); ok {
+		return setParsed(field, value, parse)
+	}
+
+	if decode, ok := typeDecoders[field.Type()]; ok {
+		return decode(field, value, opts)
+	}
+
+	if reflect.PointerTo(field.Type()).Implements(textUnmarshalerType) {
+		return setText(field, value)
+	}
+
+	if isStringerEnum(field.Type()) {
+		return setStringer(field, value, opts)
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(value)
@@ -21,25 +83,50 @@ func setValue(field reflect.Value, value string) error {
 			field.SetBool(false)
 			return nil
 		}
-		v, err := strconv.ParseBool(value)
+		v, err := parseBool(value)
 		if err != nil {
 			return err
 		}
 		field.SetBool(v)
 
-	case reflect.Int, reflect.Int64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if value == "" {
 			field.SetInt(0)
 			return nil
 		}
-		v, err := strconv.ParseInt(value, 10, 64)
+		v, err := strconv.ParseInt(value, 10, field.Type().Bits())
 		if err != nil {
 			return err
 		}
 		field.SetInt(v)
 
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value == "" {
+			field.SetUint(0)
+			return nil
+		}
+		v, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+
+	case reflect.Float32, reflect.Float64:
+		if value == "" {
+			field.SetFloat(0)
+			return nil
+		}
+		v, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(v)
+
 	case reflect.Slice, reflect.Array:
-		return setSliceOrArray(field, value)
+		return setSliceOrArray(field, value, opts)
+
+	case reflect.Ptr:
+		return setPointer(field, value, opts)
 
 	default:
 		return fmt.Errorf("unsupported kind: %s", field.Kind())
@@ -48,12 +135,29 @@ func setValue(field reflect.Value, value string) error {
 	return nil
 }
 
-func setSliceOrArray(field reflect.Value, value string) error {
+// setDuration parses a time.Duration such as "1h30m".
+func setDuration(field reflect.Value, value string, _ decodeOptions) error {
+	if value == "" {
+		field.SetInt(0)
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	field.SetInt(int64(d))
+	return nil
+}
+
+func setSliceOrArray(field reflect.Value, value string, opts decodeOptions) error {
 	elemType := field.Type().Elem()
 
-	// Проверяем, что элемент массива/слайса имеет тип int
-	if elemType.Kind() != reflect.Int && elemType.Kind() != reflect.Int64 {
-		return fmt.Errorf("unsupported slice/array element type: %s", elemType.Kind())
+	// Вложенные списки не поддерживаются: элементы разделяются той же запятой
+	if !isLeafType(elemType) {
+		switch elemType.Kind() {
+		case reflect.Slice, reflect.Array:
+			return fmt.Errorf("unsupported slice/array element type: %s", elemType)
+		}
 	}
 
 	// Если значение пустое, создаем пустой слайс/массив
@@ -62,15 +166,55 @@ func setSliceOrArray(field reflect.Value, value string) error {
 			field.Set(reflect.MakeSlice(field.Type(), 0, 0))
 		} else {
 			// Для массива оставляем нулевые значения
-			for i := 0; i < field.Len(); i++ {
-				field.Index(i).SetInt(0)
-			}
+			field.Set(reflect.Zero(field.Type()))
 		}
 		return nil
 	}
 
-	// Разделяем строку по запятым
-	parts := strings.Split(value, ",")
+	// Целочисленные списки принимают диапазоны вида 8080-8085
+	ranges := opts.unit == "" && !isLeafType(elemType)
+	switch elemType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		ranges = false
+	}
+
+	// Разделяем строку по запятым, пустые элементы обрабатываем согласно политике.
+	// positions хранит номер исходного элемента для каждого значения: в ошибках
+	// указывается позиция в переменной, а не после раскрытия диапазонов
+	var parts []string
+	var positions []int
+	for i, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			switch opts.empty {
+			case EmptyAsUnset:
+				continue
+			case EmptyIsError:
+				return fmt.Errorf("invalid %s value at index %d: %w", elemType, i, ErrEmptyValue)
+			}
+		}
+		if ranges && part != "" {
+			expanded, err := expandRange(part)
+			if err != nil {
+				if opts.secret {
+					err = redactError(err, part)
+				}
+				return fmt.Errorf("invalid %s value at index %d: %w", elemType, i, err)
+			}
+			if len(parts)+len(expanded) > maxRangeSize {
+				return fmt.Errorf("list has more than %d values", maxRangeSize)
+			}
+			parts = append(parts, expanded...)
+			for range expanded {
+				positions = append(positions, i)
+			}
+			continue
+		}
+		parts = append(parts, part)
+		positions = append(positions, i)
+	}
 
 	// Для массива проверяем, что количество элементов совпадает
 	if field.Kind() == reflect.Array {
@@ -79,34 +223,92 @@ func setSliceOrArray(field reflect.Value, value string) error {
 		}
 	}
 
+	// Заполняем новый слайс/массив, чтобы при ошибке поле осталось нетронутым
+	target := reflect.New(field.Type()).Elem()
+	if field.Kind() == reflect.Slice {
+		target = reflect.MakeSlice(field.Type(), len(parts), len(parts))
+	}
+
 	// Парсим каждое значение
-	intValues := make([]int64, 0, len(parts))
 	for i, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			intValues = append(intValues, 0)
-			continue
-		}
-		v, err := strconv.ParseInt(part, 10, 64)
-		if err != nil {
-			return fmt.Errorf("invalid int value at index %d: %w", i, err)
+		if err := setValue(target.Index(i), part, opts); err != nil {
+			// Сообщения об ошибках элементов содержат сам элемент: маскируем его
+			if opts.secret {
+				err = redactError(err, part)
+			}
+			return fmt.Errorf("invalid %s value at index %d: %w", elemType, positions[i], err)
 		}
-		intValues = append(intValues, v)
 	}
 
-	// Для слайса создаем новый слайс нужного размера
-	if field.Kind() == reflect.Slice {
-		slice := reflect.MakeSlice(field.Type(), len(intValues), len(intValues))
-		for i, v := range intValues {
-			slice.Index(i).SetInt(v)
-		}
-		field.Set(slice)
-	} else {
-		// Для массива устанавливаем значения
-		for i, v := range intValues {
-			field.Index(i).SetInt(v)
+	field.Set(target)
+	return nil
+}
+
+// maxRangeSize caps the number of values integer ranges in a list expand to.
+const maxRangeSize = 65536
+
+// expandRange expands an integer range such as "8080-8085" into its values.
+// A part that is not a range, such as "9000" or "-5", is returned unchanged.
+// Both bounds may be negative: "-5--1".
+func expandRange(part string) ([]string, error) {
+	sep := strings.Index(part[1:], "-") + 1
+	if sep == 0 {
+		return []string{part}, nil
+	}
+
+	from, err := strconv.ParseInt(strings.TrimSpace(part[:sep]), 10, 64)
+	if err != nil {
+		return []string{part}, nil
+	}
+	to, err := strconv.ParseInt(strings.TrimSpace(part[sep+1:]), 10, 64)
+	if err != nil {
+		return []string{part}, nil
+	}
+
+	if from > to {
+		return nil, fmt.Errorf("range %s is reversed", part)
+	}
+	if uint64(to-from) >= maxRangeSize {
+		return nil, fmt.Errorf("range %s has more than %d values", part, maxRangeSize)
+	}
+
+	values := make([]string, 0, to-from+1)
+	for v := from; ; v++ {
+		values = append(values, strconv.FormatInt(v, 10))
+		if v == to {
+			break
 		}
 	}
+	return values, nil
+}
+
+// setText decodes types implementing encoding.TextUnmarshaler,
+// such as netip.Addr and HostPort. An empty value sets the zero value.
+func setText(field reflect.Value, value string) error {
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	// Декодируем в новое значение, чтобы при ошибке поле осталось нетронутым
+	target := reflect.New(field.Type())
+	if err := target.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+		return err
+	}
+	field.Set(target.Elem())
+	return nil
+}
 
+// setPointer decodes the value into a newly allocated element.
+// An empty value sets the pointer to nil.
+func setPointer(field reflect.Value, value string, opts decodeOptions) error {
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	target := reflect.New(field.Type().Elem())
+	if err := setValue(target.Elem(), value, opts); err != nil {
+		return err
+	}
+	field.Set(target)
 	return nil
 }
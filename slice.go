@@ -0,0 +1,88 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// walkSlice appends the specs of the elements of a slice of structs to specs.
+// The fields of element i use the prefix followed by "<i>_", e.g. UPSTREAMS_0_HOST.
+//
+// When loading, the slice is replaced by one with as many elements as there are
+// consecutive indices in the environment. When describing the struct, a single
+// placeholder element is walked. The prefix must not be empty, or any
+// variable starting with digits and "_" would count as an element.
+func (l *loader) walkSlice(field reflect.Value, path, prefix string, specs *[]fieldSpec) error {
+	if prefix == "" {
+		return fmt.Errorf("field %s: slice of structs requires a prefix tag", path)
+	}
+
+	walkElem := func(elem reflect.Value, index string) error {
+		return l.walkFields(elem, path+"["+index+"]", prefix+index+"_", specs)
+	}
+
+	switch l.mode {
+	case walkSchema:
+		return walkElem(reflect.New(field.Type().Elem()).Elem(), l.indexPlaceholder)
+	case walkLoad:
+		n, err := l.countIndexed(prefix)
+		if err != nil {
+			return err
+		}
+		if field.CanSet() {
+			if n == 0 {
+				field.Set(reflect.Zero(field.Type()))
+			} else {
+				field.Set(reflect.MakeSlice(field.Type(), n, n))
+			}
+		}
+	}
+
+	for i := 0; i < field.Len(); i++ {
+		if err := walkElem(field.Index(i), strconv.Itoa(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countIndexed returns the number of elements of an indexed list stored in
+// variables named <prefix><index>_<field>. Indices must start at 0 and
+// have no gaps.
+func (l *loader) countIndexed(prefix string) (int, error) {
+	indices := make(map[int]bool)
+	for _, name := range l.source.Keys() {
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		digits, _, ok := strings.Cut(rest, "_")
+		if !ok {
+			continue
+		}
+		if i, err := strconv.Atoi(digits); err == nil && i >= 0 && strconv.Itoa(i) == digits {
+			indices[i] = true
+		}
+	}
+
+	found := make([]int, 0, len(indices))
+	for i := range indices {
+		found = append(found, i)
+	}
+	sort.Ints(found)
+
+	for i, index := range found {
+		if index != i {
+			parts := make([]string, len(found))
+			for j, index := range found {
+				parts[j] = strconv.Itoa(index)
+			}
+			return 0, fmt.Errorf("env %s<N>_*: index %d is missing (found %s)", prefix, i, strings.Join(parts, ", "))
+		}
+	}
+
+	return len(found), nil
+}
@@ -0,0 +1,175 @@
+package envconfig
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type upstream struct {
+	Host string `env:"HOST" required:"true"`
+	Port int    `env:"PORT" default:"80"`
+}
+
+type sliceConfig struct {
+	Name      string     `env:"SLICE_NAME"`
+	Upstreams []upstream `prefix:"UPSTREAMS_"`
+}
+
+func TestLoadStructSliceOfStructs(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    []upstream
+		wantErr string
+	}{
+		{
+			name: "no elements",
+			env:  map[string]string{},
+			want: nil,
+		},
+		{
+			name: "two elements",
+			env: map[string]string{
+				"UPSTREAMS_0_HOST": "a.local",
+				"UPSTREAMS_0_PORT": "8080",
+				"UPSTREAMS_1_HOST": "b.local",
+			},
+			want: []upstream{{Host: "a.local", Port: 8080}, {Host: "b.local", Port: 80}},
+		},
+		{
+			name: "gap in indices",
+			env: map[string]string{
+				"UPSTREAMS_0_HOST": "a.local",
+				"UPSTREAMS_2_HOST": "c.local",
+			},
+			wantErr: "env UPSTREAMS_<N>_*: index 1 is missing (found 0, 2)",
+		},
+		{
+			name: "missing first index",
+			env: map[string]string{
+				"UPSTREAMS_1_HOST": "b.local",
+			},
+			wantErr: "index 0 is missing (found 1)",
+		},
+		{
+			name: "non-canonical index is ignored",
+			env: map[string]string{
+				"UPSTREAMS_0_HOST":  "a.local",
+				"UPSTREAMS_01_HOST": "b.local",
+			},
+			want: []upstream{{Host: "a.local", Port: 80}},
+		},
+		{
+			name: "element validation names the index",
+			env: map[string]string{
+				"UPSTREAMS_0_HOST": "a.local",
+				"UPSTREAMS_1_PORT": "81",
+			},
+			wantErr: "env UPSTREAMS_1_HOST: required variable is not set",
+		},
+		{
+			name: "element decode error names the index",
+			env: map[string]string{
+				"UPSTREAMS_0_HOST": "a.local",
+				"UPSTREAMS_0_PORT": "eighty",
+			},
+			wantErr: "env UPSTREAMS_0_PORT:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			var cfg sliceConfig
+			err := LoadStruct(&cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadStruct() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadStruct() error = %v", err)
+			}
+
+			if len(cfg.Upstreams) != len(tt.want) || (tt.want == nil) != (cfg.Upstreams == nil) {
+				t.Fatalf("Upstreams = %+v, want %+v", cfg.Upstreams, tt.want)
+			}
+			for i := range tt.want {
+				if cfg.Upstreams[i] != tt.want[i] {
+					t.Errorf("Upstreams[%d] = %+v, want %+v", i, cfg.Upstreams[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadStructSliceOfStructsWithoutPrefix(t *testing.T) {
+	var cfg struct {
+		Upstreams []upstream
+	}
+	src := WithSource(MapSource{"3_HOST": "x"})
+
+	want := "field Upstreams: slice of structs requires a prefix tag"
+	if err := LoadStruct(&cfg, src); err == nil || err.Error() != want {
+		t.Errorf("LoadStruct() error = %v, want %q", err, want)
+	}
+	if err := CheckStruct(&cfg); err == nil || err.Error() != want {
+		t.Errorf("CheckStruct() error = %v, want %q", err, want)
+	}
+}
+
+func TestLoadStructSliceOfStructsMetadata(t *testing.T) {
+	resetDotEnv(t)
+	t.Setenv("UPSTREAMS_0_HOST", "a.local")
+	t.Setenv("UPSTREAMS_1_HOST", "b.local")
+
+	var cfg sliceConfig
+	md := &Metadata{}
+	if err := LoadStruct(&cfg, WithMetadata(md), Strict("UPSTREAMS_")); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if got, _ := md.Origin("Upstreams[1].Host"); got.Kind != SourceEnv || got.Key != "UPSTREAMS_1_HOST" {
+		t.Errorf("Origin(Upstreams[1].Host) = %+v", got)
+	}
+	if got, _ := md.Origin("Upstreams[1].Port"); got.Kind != SourceDefault {
+		t.Errorf("Origin(Upstreams[1].Port) = %+v", got)
+	}
+}
+
+func TestUsageSliceOfStructs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Usage(&buf, &sliceConfig{}); err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"UPSTREAMS_<N>_HOST", "UPSTREAMS_<N>_PORT"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Usage() output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDumpSliceOfStructs(t *testing.T) {
+	cfg := sliceConfig{Upstreams: []upstream{{Host: "a.local", Port: 1}, {Host: "b.local", Port: 2}}}
+
+	entries, err := Dump(&cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Env+"="+e.Value)
+	}
+	want := "SLICE_NAME=,UPSTREAMS_0_HOST=a.local,UPSTREAMS_0_PORT=1,UPSTREAMS_1_HOST=b.local,UPSTREAMS_1_PORT=2"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Dump() = %v, want %s", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package envconfig
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Source is an enumerable set of variables that LoadStruct and Lookup read from.
+// Point lookups are enough for plain fields; Keys is needed to discover the
+// elements of slices and maps of structs and the unknown variables in strict mode.
+type Source interface {
+	// Lookup returns the value of the variable key and whether it is set.
+	Lookup(key string) (string, bool)
+	// Keys returns the names of all variables in the source.
+	Keys() []string
+}
+
+// Environment is the Source backed by the process environment.
+// It is used when no WithSource option is given.
+var Environment Source = environment{}
+
+type environment struct{}
+
+func (environment) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (environment) Keys() []string {
+	env := os.Environ()
+	keys := make([]string, 0, len(env))
+	for _, kv := range env {
+		key, _, _ := strings.Cut(kv, "=")
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// MapSource is a Source backed by a map, e.g. for tests or values
+// fetched from a secret store.
+type MapSource map[string]string
+
+func (m MapSource) Lookup(key string) (string, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+func (m MapSource) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WithSource makes LoadStruct and Lookup read variables from src instead of
+// the process environment. Values from src are reported with Kind SourceEnv.
+//
+// Example:
+//
+//	err := envconfig.LoadStruct(&cfg, envconfig.WithSource(envconfig.MapSource{
+//	    "PORT": "8080",
+//	}))
+func WithSource(src Source) Option {
+	return func(l *loader) {
+		l.source = src
+	}
+}
+
+// fromEnvironment reports whether the loader reads the process environment,
+// which is where Load puts the variables of .env files.
+func (l *loader) fromEnvironment() bool {
+	_, ok := l.source.(environment)
+	return ok
+}
@@ -0,0 +1,60 @@
+package envconfig
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setLocation loads a *time.Location by its IANA name, e.g. "Europe/Berlin".
+// An empty value sets the field to nil.
+func setLocation(field reflect.Value, value string, _ decodeOptions) error {
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(loc))
+	return nil
+}
+
+// setRegexp compiles a *regexp.Regexp. An empty value sets the field to nil.
+func setRegexp(field reflect.Value, value string, _ decodeOptions) error {
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(re))
+	return nil
+}
+
+// setFileMode parses an os.FileMode in octal notation: "0644", "644" or "0o644".
+func setFileMode(field reflect.Value, value string, _ decodeOptions) error {
+	if value == "" {
+		field.SetUint(0)
+		return nil
+	}
+	digits := strings.TrimPrefix(strings.TrimPrefix(value, "0o"), "0O")
+	v, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil {
+		return fmt.Errorf("invalid file mode %q: must be octal, e.g. 0644", value)
+	}
+	field.SetUint(v)
+	return nil
+}
+
+// formatFileMode formats an os.FileMode in the octal notation setFileMode accepts.
+func formatFileMode(mode os.FileMode) string {
+	return fmt.Sprintf("%#o", uint32(mode))
+}
@@ -0,0 +1,97 @@
+package envconfig
+
+import (
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stdConfig struct {
+	LogLevel slog.Level     `env:"STD_LOG_LEVEL" default:"info"`
+	TZ       *time.Location `env:"STD_TZ"`
+	Pattern  *regexp.Regexp `env:"STD_PATTERN"`
+	Mode     os.FileMode    `env:"STD_MODE" default:"0644"`
+}
+
+func TestLoadStructStdTypes(t *testing.T) {
+	t.Setenv("STD_LOG_LEVEL", "warn+2")
+	t.Setenv("STD_TZ", "UTC")
+	t.Setenv("STD_PATTERN", `^user-\d+$`)
+	t.Setenv("STD_MODE", "0o750")
+
+	var cfg stdConfig
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.LogLevel != slog.LevelWarn+2 {
+		t.Errorf("LogLevel = %v, want WARN+2", cfg.LogLevel)
+	}
+	if cfg.TZ != time.UTC {
+		t.Errorf("TZ = %v, want UTC", cfg.TZ)
+	}
+	if cfg.Pattern == nil || !cfg.Pattern.MatchString("user-42") {
+		t.Errorf("Pattern = %v", cfg.Pattern)
+	}
+	if cfg.Mode != 0o750 {
+		t.Errorf("Mode = %#o, want 0750", cfg.Mode)
+	}
+}
+
+func TestLoadStructStdTypesDefaults(t *testing.T) {
+	var cfg stdConfig
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+	if cfg.LogLevel != slog.LevelInfo || cfg.TZ != nil || cfg.Pattern != nil || cfg.Mode != 0o644 {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+}
+
+func TestLoadStructStdTypesErrors(t *testing.T) {
+	tests := []struct {
+		key     string
+		value   string
+		wantErr string
+	}{
+		{"STD_LOG_LEVEL", "verbose", "env STD_LOG_LEVEL: slog: level string \"verbose\": unknown name"},
+		{"STD_TZ", "Mars/Olympus", "env STD_TZ: unknown time zone Mars/Olympus"},
+		{"STD_PATTERN", "user-(", "env STD_PATTERN: error parsing regexp: missing closing )"},
+		{"STD_MODE", "0999", `env STD_MODE: invalid file mode "0999": must be octal, e.g. 0644`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+
+			var cfg stdConfig
+			if err := LoadStruct(&cfg); err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("LoadStruct() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDumpStdTypes(t *testing.T) {
+	cfg := stdConfig{
+		LogLevel: slog.LevelDebug,
+		TZ:       time.UTC,
+		Pattern:  regexp.MustCompile(`^a+$`),
+		Mode:     0o600,
+	}
+
+	entries, err := Dump(&cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	want := []string{"DEBUG", "UTC", "^a+$", "0600"}
+	for i, e := range entries {
+		if e.Value != want[i] {
+			t.Errorf("Dump() %s = %q, want %q", e.Env, e.Value, want[i])
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// UnknownVariableError reports a variable that no struct field maps to.
+type UnknownVariableError struct {
+	// Name is the name of the unknown variable.
+	Name string
+	// File and Line locate the variable if it was read from a .env file by Load.
+	File string
+	Line int
+	// Suggestion is the closest known variable name, if any is close enough.
+	Suggestion string
+}
+
+func (e *UnknownVariableError) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File)
+		if e.Line > 0 {
+			fmt.Fprintf(&sb, ":%d", e.Line)
+		}
+		sb.WriteString(": ")
+	}
+	fmt.Fprintf(&sb, "unknown variable %s", e.Name)
+	if e.Suggestion != "" {
+		fmt.Fprintf(&sb, " (did you mean %s?)", e.Suggestion)
+	}
+	return sb.String()
+}
+
+// Strict makes LoadStruct fail if the environment contains a variable
+// starting with prefix, or Load read a .env key, that no field maps to.
+// The error joins an *UnknownVariableError for every such variable.
+//
+// Example:
+//
+//	// APP_DATBASE_URL is set: unknown variable APP_DATBASE_URL (did you mean APP_DATABASE_URL?)
+//	err := envconfig.LoadStruct(&cfg, envconfig.Strict("APP_"))
+func Strict(prefix string) Option {
+	return func(l *loader) {
+		l.strict = true
+		l.strictPrefix = prefix
+		l.strictWarn = false
+	}
+}
+
+// StrictWarn is like Strict, but reports unknown variables to the warning
+// handler (see OnWarning) instead of failing.
+func StrictWarn(prefix string) Option {
+	return func(l *loader) {
+		l.strict = true
+		l.strictPrefix = prefix
+		l.strictWarn = true
+	}
+}
+
+// OnWarning sets the function that receives non-fatal problems found by LoadStruct,
+// such as unknown variables reported by StrictWarn and uses of deprecated names.
+// By default warnings are written with the standard logger.
+func OnWarning(fn func(err error)) Option {
+	return func(l *loader) {
+		l.warn = fn
+	}
+}
+
+// defaultWarn is the warning handler used when OnWarning is not given.
+func defaultWarn(err error) {
+	log.Printf("envconfig: %v", err)
+}
+
+// checkUnknown reports variables under the strict prefix and .env keys
+// that are not in known.
+func (l *loader) checkUnknown(specs []fieldSpec) error {
+	known := make(map[string]bool, len(specs)*2)
+	names := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		for _, name := range spec.names() {
+			known[name] = true
+		}
+		known[spec.envName+FileSuffix] = true
+		names = append(names, spec.envName)
+	}
+	known[EnvFileKey] = true
+
+	candidates := make(map[string]Origin)
+	if l.fromEnvironment() {
+		candidates = dotenvKeys()
+	}
+	for _, name := range l.source.Keys() {
+		if _, ok := candidates[name]; !ok && strings.HasPrefix(name, l.strictPrefix) {
+			candidates[name] = Origin{Kind: SourceEnv, Key: name}
+		}
+	}
+
+	var unknown []*UnknownVariableError
+	for name, origin := range candidates {
+		if known[name] {
+			continue
+		}
+		unknown = append(unknown, &UnknownVariableError{
+			Name:       name,
+			File:       origin.File,
+			Line:       origin.Line,
+			Suggestion: suggest(name, names),
+		})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Name < unknown[j].Name })
+
+	errs := make([]error, 0, len(unknown))
+	for _, u := range unknown {
+		if l.strictWarn {
+			l.warn(u)
+			continue
+		}
+		errs = append(errs, u)
+	}
+	return errors.Join(errs...)
+}
+
+// suggest returns the name in names closest to name by edit distance,
+// or "" if none is close enough to be a likely typo.
+func suggest(name string, names []string) string {
+	best, bestDist := "", len(name)/4+2
+	for _, candidate := range names {
+		if d := editDistance(name, candidate); d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
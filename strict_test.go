@@ -0,0 +1,126 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type strictConfig struct {
+	DatabaseURL string `env:"TESTSTRICT_DATABASE_URL"`
+	Port        int    `env:"TESTSTRICT_PORT" default:"8080"`
+}
+
+// resetDotEnv hides variables recorded by earlier Load calls for the duration of the test.
+func resetDotEnv(t *testing.T) {
+	t.Helper()
+
+	dotenvMu.Lock()
+	saved := dotenvEntries
+	dotenvEntries = make(map[string]dotenvEntry)
+	dotenvMu.Unlock()
+
+	t.Cleanup(func() {
+		dotenvMu.Lock()
+		dotenvEntries = saved
+		dotenvMu.Unlock()
+	})
+}
+
+func TestLoadStructStrict(t *testing.T) {
+	resetDotEnv(t)
+	t.Setenv("TESTSTRICT_DATBASE_URL", "postgres://localhost")
+	t.Setenv("TESTSTRICT_PORT", "9000")
+	t.Setenv("TESTSTRICT_DATABASE_URL_FILE", "/dev/null")
+	t.Setenv("TESTSTRICT_COMPLETELY_UNRELATED", "1")
+	t.Setenv("OTHER_VARIABLE", "1")
+
+	var cfg strictConfig
+	err := LoadStruct(&cfg, Strict("TESTSTRICT_"))
+	if err == nil {
+		t.Fatal("LoadStruct() error = nil, want unknown variables")
+	}
+
+	var unknown *UnknownVariableError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("LoadStruct() error = %v, want *UnknownVariableError", err)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"unknown variable TESTSTRICT_COMPLETELY_UNRELATED\n",
+		"unknown variable TESTSTRICT_DATBASE_URL (did you mean TESTSTRICT_DATABASE_URL?)",
+	} {
+		if !strings.Contains(msg+"\n", want) {
+			t.Errorf("LoadStruct() error = %q, want it to contain %q", msg, want)
+		}
+	}
+	for _, unwanted := range []string{"TESTSTRICT_PORT", "_FILE", "OTHER_VARIABLE"} {
+		if strings.Contains(msg, unwanted) {
+			t.Errorf("LoadStruct() error = %q, must not report %s", msg, unwanted)
+		}
+	}
+}
+
+func TestLoadStructStrictWarn(t *testing.T) {
+	resetDotEnv(t)
+	t.Setenv("TESTSTRICT_PROT", "9000")
+
+	var warnings []error
+	var cfg strictConfig
+	err := LoadStruct(&cfg, StrictWarn("TESTSTRICT_"), OnWarning(func(err error) {
+		warnings = append(warnings, err)
+	}))
+	if err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("warnings = %v, want 1", warnings)
+	}
+	want := "unknown variable TESTSTRICT_PROT (did you mean TESTSTRICT_PORT?)"
+	if warnings[0].Error() != want {
+		t.Errorf("warning = %q, want %q", warnings[0], want)
+	}
+}
+
+func TestLoadStructStrictDotEnv(t *testing.T) {
+	resetDotEnv(t)
+
+	envFile := filepath.Join(t.TempDir(), "strict.env")
+	if err := os.WriteFile(envFile, []byte("TESTSTRICT_PORT=1\nLOG_LEVLE=debug\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvFileKey, envFile)
+	defer os.Unsetenv("TESTSTRICT_PORT")
+	defer os.Unsetenv("LOG_LEVLE")
+	if err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var cfg strictConfig
+	err := LoadStruct(&cfg, Strict("TESTSTRICT_"))
+	want := envFile + ":2: unknown variable LOG_LEVLE"
+	if err == nil || err.Error() != want {
+		t.Errorf("LoadStruct() error = %v, want %q", err, want)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"DATBASE", "DATABASE", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package envconfig
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// TransformFunc transforms a raw value before it is decoded. arg is the text
+// after "=" in the "transform" tag, e.g. "v" for trimprefix=v, or "".
+type TransformFunc func(value, arg string) string
+
+var (
+	transformsMu sync.RWMutex
+	transforms   = map[string]TransformFunc{
+		"trim":       func(value, _ string) string { return strings.TrimSpace(value) },
+		"lower":      func(value, _ string) string { return strings.ToLower(value) },
+		"upper":      func(value, _ string) string { return strings.ToUpper(value) },
+		"trimprefix": strings.TrimPrefix,
+		"trimsuffix": strings.TrimSuffix,
+		"squash":     func(value, _ string) string { return strings.Join(strings.Fields(value), " ") },
+		"nospace": func(value, _ string) string {
+			return strings.Map(func(r rune) rune {
+				if unicode.IsSpace(r) {
+					return -1
+				}
+				return r
+			}, value)
+		},
+	}
+)
+
+// RegisterTransform registers fn under name for use in "transform" tags.
+// Registering a name again replaces the transform, including the built-in
+// ones: trim, lower, upper, trimprefix, trimsuffix, squash and nospace.
+//
+// Example:
+//
+//	envconfig.RegisterTransform("nodashes", func(value, _ string) string {
+//	    return strings.ReplaceAll(value, "-", "")
+//	})
+func RegisterTransform(name string, fn TransformFunc) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	transforms[name] = fn
+}
+
+// lookupTransform returns the transform registered under name, if any.
+func lookupTransform(name string) (TransformFunc, bool) {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+	fn, ok := transforms[name]
+	return fn, ok
+}
+
+// applyTransforms applies the transforms listed in a "transform" tag,
+// such as "trim,lower" or "trim,trimprefix=v", from left to right.
+func applyTransforms(value, tag string) (string, error) {
+	if tag == "" {
+		return value, nil
+	}
+	for _, item := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(item), "=")
+		fn, ok := lookupTransform(name)
+		if !ok {
+			return "", fmt.Errorf("unknown transform %q", name)
+		}
+		value = fn(value, arg)
+	}
+	return value, nil
+}
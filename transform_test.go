@@ -0,0 +1,81 @@
+package envconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyTransforms(t *testing.T) {
+	tests := []struct {
+		value   string
+		tag     string
+		want    string
+		wantErr string
+	}{
+		{value: "  Prod \n", tag: "trim,lower", want: "prod"},
+		{value: "eu-west", tag: "upper", want: "EU-WEST"},
+		{value: " v1.2.3", tag: "trim, trimprefix=v", want: "1.2.3"},
+		{value: "app.example.com.", tag: "trimsuffix=.", want: "app.example.com"},
+		{value: " a   b\tc ", tag: "squash", want: "a b c"},
+		{value: "10.0.0.0/8, 192.168.0.0/16", tag: "nospace", want: "10.0.0.0/8,192.168.0.0/16"},
+		{value: "x", tag: "", want: "x"},
+		{value: "x", tag: "trim,reverse", wantErr: `unknown transform "reverse"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := applyTransforms(tt.value, tt.tag)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("applyTransforms() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("applyTransforms(%q, %q) = %q, %v, want %q", tt.value, tt.tag, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadStructTransform(t *testing.T) {
+	RegisterTransform("nodashes", func(value, _ string) string {
+		return strings.ReplaceAll(value, "-", "")
+	})
+
+	secret := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secret, []byte("  abc-def \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TRANSFORM_ENV", " Production ")
+	t.Setenv("TRANSFORM_MODE", " FAST")
+	t.Setenv("TRANSFORM_PORT", "   ")
+	t.Setenv("TRANSFORM_TOKEN_FILE", secret)
+
+	var cfg struct {
+		Env   string `env:"TRANSFORM_ENV" transform:"trim,lower" enum:"production,staging"`
+		Mode  mode   `env:"TRANSFORM_MODE" transform:"trim,lower" enum:"fast=1,safe=2"`
+		Port  int    `env:"TRANSFORM_PORT" transform:"trim" default:"8080"`
+		Token string `env:"TRANSFORM_TOKEN" transform:"trim,nodashes"`
+	}
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.Env != "production" || cfg.Mode != 1 || cfg.Port != 8080 || cfg.Token != "abcdef" {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+}
+
+func TestCheckStructTransform(t *testing.T) {
+	var cfg struct {
+		Name string `env:"TRANSFORM_NAME" transform:"trim,titlecase"`
+	}
+	err := CheckStruct(&cfg)
+	if err == nil || !strings.Contains(err.Error(), `field Name (env TRANSFORM_NAME): unknown transform "titlecase"`) {
+		t.Errorf("CheckStruct() error = %v", err)
+	}
+}
@@ -0,0 +1,263 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ByteSize is a number of bytes written in human-friendly form, such as
+// "512KiB", "10MB" or "1.5G". Units with "i" are powers of 1024,
+// the others are powers of 1000; units are case-insensitive and "B" is optional.
+//
+// Plain integer fields can be decoded the same way with the tag unit:"bytes".
+//
+// Example:
+//
+//	type Config struct {
+//	    MaxUpload envconfig.ByteSize `env:"MAX_UPLOAD" default:"10MiB"`
+//	    MaxBody   int64              `env:"MAX_BODY" default:"1MB" unit:"bytes"`
+//	}
+type ByteSize int64
+
+// byteUnits are the units ByteSize accepts, largest first within each base.
+var byteUnits = []struct {
+	name string
+	size int64
+}{
+	{"EiB", 1 << 60}, {"PiB", 1 << 50}, {"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	{"EB", 1e18}, {"PB", 1e15}, {"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"KB", 1e3},
+	{"B", 1},
+}
+
+// ParseByteSize parses a byte size such as "512KiB", "10MB", "1.5G" or "4096".
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return unicode.IsLetter(r) })
+	number, unit := s, ""
+	if i >= 0 {
+		number, unit = strings.TrimSpace(s[:i]), s[i:]
+	}
+
+	size, ok := byteUnitSize(unit)
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, unit)
+	}
+
+	if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if n < 0 {
+			return 0, fmt.Errorf("invalid byte size %q: must not be negative", s)
+		}
+		if n > math.MaxInt64/size {
+			return 0, fmt.Errorf("invalid byte size %q: too large", s)
+		}
+		return ByteSize(n * size), nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	bytes := f * float64(size)
+	switch {
+	case f < 0:
+		return 0, fmt.Errorf("invalid byte size %q: must not be negative", s)
+	case bytes >= math.MaxInt64:
+		return 0, fmt.Errorf("invalid byte size %q: too large", s)
+	case bytes != math.Trunc(bytes):
+		return 0, fmt.Errorf("invalid byte size %q: not a whole number of bytes", s)
+	}
+	return ByteSize(bytes), nil
+}
+
+// byteUnitSize returns the number of bytes in unit, e.g. 1024 for "KiB" or "Ki".
+func byteUnitSize(unit string) (int64, bool) {
+	if unit == "" {
+		return 1, true
+	}
+	for _, u := range byteUnits {
+		if strings.EqualFold(unit, u.name) || strings.EqualFold(unit, strings.TrimSuffix(u.name, "B")) {
+			return u.size, true
+		}
+	}
+	return 0, false
+}
+
+// String returns the size in the largest unit that represents it exactly,
+// e.g. "512KiB", "10MB" or "1000B".
+func (b ByteSize) String() string {
+	if b == 0 {
+		return "0B"
+	}
+	best := byteUnits[len(byteUnits)-1]
+	for _, u := range byteUnits {
+		if int64(b)%u.size == 0 && u.size > best.size {
+			best = u
+		}
+	}
+	return strconv.FormatInt(int64(b)/best.size, 10) + best.name
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	v, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// Rate is a number of events per period, written as "100/s", "5000/m",
+// "10/h" or with any duration as the period, e.g. "10/30s".
+//
+// Example:
+//
+//	type Config struct {
+//	    Limit envconfig.Rate `env:"RATE_LIMIT" default:"100/s"`
+//	}
+//	limiter := rate.NewLimiter(rate.Limit(cfg.Limit.PerSecond()), burst)
+type Rate struct {
+	Count int
+	Per   time.Duration
+}
+
+// rateUnits are the period abbreviations Rate accepts and prints.
+var rateUnits = []struct {
+	name string
+	per  time.Duration
+}{
+	{"ms", time.Millisecond}, {"s", time.Second}, {"m", time.Minute}, {"h", time.Hour},
+}
+
+// ParseRate parses a rate such as "100/s", "5000/m" or "10/30s".
+func ParseRate(s string) (Rate, error) {
+	count, period, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Rate{}, fmt.Errorf("invalid rate %q: must be <count>/<period>, e.g. 100/s", s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || n < 0 {
+		return Rate{}, fmt.Errorf("invalid rate %q: count must be a non-negative integer", s)
+	}
+
+	period = strings.TrimSpace(period)
+	per := time.Duration(0)
+	for _, u := range rateUnits {
+		if period == u.name {
+			per = u.per
+			break
+		}
+	}
+	if per == 0 {
+		if per, err = time.ParseDuration(period); err != nil || per <= 0 {
+			return Rate{}, fmt.Errorf("invalid rate %q: invalid period %q", s, period)
+		}
+	}
+
+	return Rate{Count: n, Per: per}, nil
+}
+
+// PerSecond returns the rate in events per second.
+func (r Rate) PerSecond() float64 {
+	if r.Per <= 0 {
+		return 0
+	}
+	return float64(r.Count) / r.Per.Seconds()
+}
+
+// String returns the rate in the form ParseRate accepts, e.g. "100/s".
+func (r Rate) String() string {
+	for _, u := range rateUnits {
+		if r.Per == u.per {
+			return strconv.Itoa(r.Count) + "/" + u.name
+		}
+	}
+	return strconv.Itoa(r.Count) + "/" + r.Per.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Rate) UnmarshalText(text []byte) error {
+	v, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// setWithUnit decodes value into an integer field, or a list of integers,
+// according to the "unit" tag. The only supported unit is "bytes".
+func setWithUnit(field reflect.Value, value string, opts decodeOptions) error {
+	unit := opts.unit
+	if unit != "bytes" {
+		return fmt.Errorf("unknown unit %q", unit)
+	}
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		return setSliceOrArray(field, value, opts)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return fmt.Errorf("unit %q requires an integer field, got %s", unit, field.Type())
+	}
+
+	if value == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	size, err := ParseByteSize(value)
+	if err != nil {
+		return err
+	}
+	if field.CanUint() {
+		if field.OverflowUint(uint64(size)) {
+			return errors.New("byte size out of range for " + field.Type().String())
+		}
+		field.SetUint(uint64(size))
+		return nil
+	}
+	if field.OverflowInt(int64(size)) {
+		return errors.New("byte size out of range for " + field.Type().String())
+	}
+	field.SetInt(int64(size))
+	return nil
+}
+
+// formatWithUnit formats an integer field in the syntax of the "unit" tag.
+func formatWithUnit(v reflect.Value, unit string) string {
+	switch {
+	case unit != "bytes":
+		return formatValue(v)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatWithUnit(v.Index(i), unit)
+		}
+		return strings.Join(parts, ",")
+	case v.CanUint():
+		return ByteSize(v.Uint()).String()
+	case v.CanInt():
+		return ByteSize(v.Int()).String()
+	default:
+		return formatValue(v)
+	}
+}
@@ -0,0 +1,170 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ByteSize
+		wantErr string
+	}{
+		{in: "4096", want: 4096},
+		{in: "0", want: 0},
+		{in: "512KiB", want: 512 << 10},
+		{in: "512 KiB", want: 512 << 10},
+		{in: "10MB", want: 10_000_000},
+		{in: "10mb", want: 10_000_000},
+		{in: "1.5G", want: 1_500_000_000},
+		{in: "1.5Gi", want: 3 << 29},
+		{in: "2TiB", want: 2 << 40},
+		{in: "100B", want: 100},
+		{in: "1.5B", wantErr: "not a whole number of bytes"},
+		{in: "-1KB", wantErr: "must not be negative"},
+		{in: "10XB", wantErr: `unknown unit "XB"`},
+		{in: "KB", wantErr: `invalid byte size "KB"`},
+		{in: "9EiB", wantErr: "too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseByteSize(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseByteSize(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseByteSize(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := []struct {
+		in   ByteSize
+		want string
+	}{
+		{0, "0B"},
+		{1536, "1536B"},
+		{512 << 10, "512KiB"},
+		{10_000_000, "10MB"},
+		{3 << 30, "3GiB"},
+		{1_500_000_000, "1500MB"},
+	}
+	for _, tt := range tests {
+		got := tt.in.String()
+		if got != tt.want {
+			t.Errorf("ByteSize(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+		if back, err := ParseByteSize(got); err != nil || back != tt.in {
+			t.Errorf("ParseByteSize(%q) = %d, %v, want %d", got, back, err, tt.in)
+		}
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Rate
+		str     string
+		wantErr string
+	}{
+		{in: "100/s", want: Rate{Count: 100, Per: time.Second}, str: "100/s"},
+		{in: "5000/m", want: Rate{Count: 5000, Per: time.Minute}, str: "5000/m"},
+		{in: "10/h", want: Rate{Count: 10, Per: time.Hour}, str: "10/h"},
+		{in: "10/30s", want: Rate{Count: 10, Per: 30 * time.Second}, str: "10/30s"},
+		{in: "10/1m", want: Rate{Count: 10, Per: time.Minute}, str: "10/m"},
+		{in: "100", wantErr: "must be <count>/<period>"},
+		{in: "x/s", wantErr: "count must be a non-negative integer"},
+		{in: "10/0s", wantErr: `invalid period "0s"`},
+		{in: "10/day", wantErr: `invalid period "day"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRate(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseRate(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseRate(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+			}
+			if got.String() != tt.str {
+				t.Errorf("String() = %q, want %q", got.String(), tt.str)
+			}
+		})
+	}
+
+	if got := (Rate{Count: 5000, Per: time.Minute}).PerSecond(); got != 5000.0/60 {
+		t.Errorf("PerSecond() = %v", got)
+	}
+}
+
+type unitsConfig struct {
+	MaxUpload ByteSize `env:"UNITS_MAX_UPLOAD" default:"10MiB"`
+	MaxBody   int64    `env:"UNITS_MAX_BODY" default:"1MB" unit:"bytes"`
+	Buffers   []uint32 `env:"UNITS_BUFFERS" unit:"bytes"`
+	Limit     Rate     `env:"UNITS_LIMIT" default:"100/s"`
+}
+
+func TestLoadStructUnits(t *testing.T) {
+	t.Setenv("UNITS_MAX_BODY", "512KiB")
+	t.Setenv("UNITS_BUFFERS", "4KiB, 64KiB")
+	t.Setenv("UNITS_LIMIT", "5000/m")
+
+	var cfg unitsConfig
+	if err := LoadStruct(&cfg); err != nil {
+		t.Fatalf("LoadStruct() error = %v", err)
+	}
+
+	if cfg.MaxUpload != 10<<20 || cfg.MaxBody != 512<<10 {
+		t.Errorf("LoadStruct() = %+v", cfg)
+	}
+	if len(cfg.Buffers) != 2 || cfg.Buffers[1] != 64<<10 {
+		t.Errorf("Buffers = %v", cfg.Buffers)
+	}
+	if cfg.Limit != (Rate{Count: 5000, Per: time.Minute}) {
+		t.Errorf("Limit = %+v", cfg.Limit)
+	}
+
+	entries, err := Dump(&cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	want := []string{"10MiB", "512KiB", "4KiB,64KiB", "5000/m"}
+	for i, e := range entries {
+		if e.Value != want[i] {
+			t.Errorf("Dump() %s = %q, want %q", e.Env, e.Value, want[i])
+		}
+	}
+}
+
+func TestLoadStructUnitsErrors(t *testing.T) {
+	var small struct {
+		Size int8 `env:"UNITS_SMALL" unit:"bytes"`
+	}
+	t.Setenv("UNITS_SMALL", "1KiB")
+	if err := LoadStruct(&small); err == nil || !strings.Contains(err.Error(), "env UNITS_SMALL: byte size out of range for int8") {
+		t.Errorf("LoadStruct() error = %v", err)
+	}
+
+	var bad struct {
+		Name string `env:"UNITS_NAME" unit:"bytes"`
+		Size int    `env:"UNITS_SIZE" unit:"bits"`
+	}
+	err := CheckStruct(&bad)
+	for _, want := range []string{`unit "bytes" requires an integer field, got string`, `unknown unit "bits"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("CheckStruct() error = %v, want %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package envconfig
+
+import (
+	"io"
+	"text/tabwriter"
+	"text/template"
+)
+
+const (
+	// UsageListFormat is a plain text template that prints one variable per block.
+	UsageListFormat = `This application is configured via the environment. The following environment
+variables can be used:
+{{range .}}
+{{.Name}}
+  [description] {{.Description}}
+  [type]        {{.Type}}
+  [default]     {{.Default}}
+  [required]    {{if .Required}}true{{end}}
+{{end}}`
+
+	// UsageTableFormat is a template that prints variables as an aligned table.
+	// It is the format used by Usage.
+	UsageTableFormat = `This application is configured via the environment. The following environment
+variables can be used:
+
+NAME	TYPE	DEFAULT	REQUIRED	DESCRIPTION
+{{range .}}{{.Name}}	{{.Type}}	{{.Default}}	{{if .Required}}true{{end}}	{{.Description}}
+{{end}}`
+)
+
+// VarInfo describes an environment variable read by LoadStruct.
+// It is passed to usage templates as the element of the ranged slice.
+type VarInfo struct {
+	// Name is the name of the environment variable.
+	Name string
+	// Path is the Go path of the struct field, e.g. "Port" or "Upstreams[<N>].Host".
+	Path string
+	// Type is the Go type of the field, e.g. "int" or "[]int".
+	Type string
+	// Default is the value of the "default" tag,
+	// or RedactedValue if the field is secret.
+	Default string
+	// Required reports whether the field is tagged with required:"true".
+	Required bool
+	// Secret reports whether the field is tagged with secret:"true".
+	Secret bool
+	// Aliases are the old names of the variable from the "aliases" tag.
+	Aliases []string
+	// Deprecated is the value of the "deprecated" tag,
+	// or "" if it is missing or false.
+	Deprecated string
+	// Description is the value of the "desc" tag.
+	Description string
+}
+
+// Usage writes a table of every environment variable LoadStruct would read
+// into cfg, using UsageTableFormat. cfg must be a pointer to a struct.
+// Options that affect variable names, such as WithAutoNames, are honoured.
+// Fields of slices of structs are listed once, with <N> in place of the index.
+//
+// Example:
+//
+//	var cfg Config
+//	if err := envconfig.LoadStruct(&cfg); err != nil {
+//	    envconfig.Usage(os.Stderr, &cfg)
+//	    os.Exit(2)
+//	}
+func Usage(w io.Writer, cfg any, opts ...Option) error {
+	return Usagef(w, cfg, UsageTableFormat, opts...)
+}
+
+// Usagef writes the usage of cfg using the given text/template format.
+// The template is executed with a []VarInfo.
+func Usagef(w io.Writer, cfg any, format string, opts ...Option) error {
+	tmpl, err := template.New("envconfig").Parse(format)
+	if err != nil {
+		return err
+	}
+	return Usaget(w, cfg, tmpl, opts...)
+}
+
+// Usaget writes the usage of cfg using the given template.
+// The template is executed with a []VarInfo, and the output is aligned
+// with a tabwriter, so tab-separated columns line up.
+func Usaget(w io.Writer, cfg any, tmpl *template.Template, opts ...Option) error {
+	l := newLoader(opts)
+	l.indexPlaceholder = "<N>"
+	l.keyPlaceholder = "<NAME>"
+	infos, err := varInfos(cfg, l)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 0, 4, ' ', 0)
+	if err := tmpl.Execute(tw, infos); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+// varInfos describes the environment variables read into cfg.
+func varInfos(cfg any, l *loader) ([]VarInfo, error) {
+	specs, err := l.collectFields(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]VarInfo, 0, len(specs))
+	for _, spec := range specs {
+		defaultValue := spec.defaultValue
+		if spec.secret && defaultValue != "" {
+			defaultValue = RedactedValue
+		}
+
+		infos = append(infos, VarInfo{
+			Name:        spec.envName,
+			Path:        spec.path,
+			Type:        spec.field.Type.String(),
+			Default:     defaultValue,
+			Required:    spec.required,
+			Secret:      spec.secret,
+			Aliases:     spec.aliases,
+			Deprecated:  spec.deprecated,
+			Description: spec.desc,
+		})
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,100 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+type usageConfig struct {
+	Host  string `env:"HOST" default:"localhost" desc:"listen address"`
+	Port  int    `env:"PORT" default:"8080"`
+	DSN   string `env:"DSN" required:"true" desc:"database connection string"`
+	Ports []int  `env:"PORTS"`
+	Skip  string
+}
+
+func TestUsage(t *testing.T) {
+	var cfg usageConfig
+	var sb strings.Builder
+	if err := Usage(&sb, &cfg); err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+
+	lines := strings.Split(sb.String(), "\n")
+	want := map[string][]string{
+		"HOST":  {"string", "localhost", "listen address"},
+		"PORT":  {"int", "8080"},
+		"DSN":   {"string", "true", "database connection string"},
+		"PORTS": {"[]int"},
+	}
+	for name, parts := range want {
+		var found string
+		for _, line := range lines {
+			if strings.HasPrefix(line, name+" ") {
+				found = line
+				break
+			}
+		}
+		if found == "" {
+			t.Errorf("Usage() has no row for %s:\n%s", name, sb.String())
+			continue
+		}
+		for _, part := range parts {
+			if !strings.Contains(found, part) {
+				t.Errorf("row %q does not contain %q", found, part)
+			}
+		}
+	}
+	if strings.Contains(sb.String(), "Skip") {
+		t.Errorf("Usage() lists untagged field:\n%s", sb.String())
+	}
+}
+
+func TestUsagef(t *testing.T) {
+	var cfg usageConfig
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "custom template",
+			format: `{{range .}}{{.Path}}={{.Name}};{{end}}`,
+			want:   "Host=HOST;Port=PORT;DSN=DSN;Ports=PORTS;",
+		},
+		{
+			name:   "list format",
+			format: UsageListFormat,
+			want:   "  [description] database connection string",
+		},
+		{
+			name:    "invalid template",
+			format:  `{{range .}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			err := Usagef(&sb, &cfg, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Usagef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(sb.String(), tt.want) {
+				t.Errorf("Usagef() = %q, want it to contain %q", sb.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestUsagetRejectsNonStruct(t *testing.T) {
+	tmpl := template.Must(template.New("t").Parse(UsageTableFormat))
+	var sb strings.Builder
+	if err := Usaget(&sb, usageConfig{}, tmpl); err == nil {
+		t.Error("Usaget() error = nil, want error for non-pointer cfg")
+	}
+}